@@ -0,0 +1,247 @@
+// Command cosmos-msgs inspects and repairs the msgs queued by the cosmos Txm.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // postgres driver
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	_ "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters/injective" // registers injective msg types
+	injectivetypes "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters/injective/types"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/txm"
+)
+
+const usage = `Usage: cosmos-msgs [flags] <command> [command flags] [args]
+
+Commands:
+  list                 list msgs, oldest first
+  cancel <id>...       cancel Unstarted or Started msgs
+  resubmit <id>...     enqueue copies of Errored msgs as new Unstarted msgs
+
+Started msgs may be about to be broadcast, so only cancel them while the node is stopped,
+or when their sender is stuck.
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	dbURL := flag.String("db", os.Getenv("CL_DATABASE_URL"), "postgres URL of the node database, defaults to $CL_DATABASE_URL")
+	chainID := flag.String("chain-id", "", "cosmos chain ID of the msgs")
+	flag.Parse()
+
+	if err := run(*dbURL, *chainID, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dbURL, chainID string, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return errors.New("missing command")
+	}
+	if dbURL == "" {
+		return errors.New("missing -db")
+	}
+	if chainID == "" {
+		return errors.New("missing -chain-id")
+	}
+	ds, err := sqlx.Open("postgres", dbURL)
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+	orm := txm.NewORM(chainID, ds)
+	ctx := context.Background()
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		return list(ctx, orm, args)
+	case "cancel":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		cancelled, err := orm.CancelMsgs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		report("cancelled", ids, cancelled)
+		return nil
+	case "resubmit":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		resubmitted, err := orm.ResubmitMsgs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("resubmitted %d of %d msgs as new msgs: %v\n", len(resubmitted), len(ids), resubmitted)
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// listedMsg is a msg as printed by list, with Raw decoded by its type.
+type listedMsg struct {
+	ID          int64           `json:"id"`
+	State       db.State        `json:"state"`
+	Reason      *db.Reason      `json:"reason,omitempty"`
+	ErrorCode   *uint32         `json:"errorCode,omitempty"`
+	ErrorLog    *string         `json:"errorLog,omitempty"`
+	ContractID  string          `json:"contractID"`
+	CoalesceKey *string         `json:"coalesceKey,omitempty"`
+	TxHash      *string         `json:"txHash,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Type        string          `json:"type"`
+	Msg         json.RawMessage `json:"msg,omitempty"`
+	DecodeError string          `json:"decodeError,omitempty"`
+}
+
+func list(ctx context.Context, orm *txm.ORM, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	states := fs.String("state", "", "comma separated states to list, e.g. unstarted,errored")
+	contract := fs.String("contract", "", "contract ID to list msgs for")
+	after := fs.String("after", "", "only list msgs created at or after this RFC3339 time")
+	before := fs.String("before", "", "only list msgs created before this RFC3339 time")
+	limit := fs.Int64("limit", 100, "maximum number of msgs to list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f := txm.MsgFilter{ContractID: *contract, Limit: *limit}
+	if *states != "" {
+		for _, s := range strings.Split(*states, ",") {
+			f.States = append(f.States, db.State(strings.TrimSpace(s)))
+		}
+	}
+	var err error
+	if f.CreatedAfter, err = parseTime(*after); err != nil {
+		return fmt.Errorf("invalid -after: %w", err)
+	}
+	if f.CreatedBefore, err = parseTime(*before); err != nil {
+		return fmt.Errorf("invalid -before: %w", err)
+	}
+
+	msgs, err := orm.ListMsgs(ctx, f)
+	if err != nil {
+		return err
+	}
+	cdc := newCodec()
+	enc := json.NewEncoder(os.Stdout)
+	for _, m := range msgs {
+		l := listedMsg{
+			ID:          m.ID,
+			State:       m.State,
+			Reason:      m.Reason,
+			ErrorCode:   m.ErrorCode,
+			ErrorLog:    m.ErrorLog,
+			ContractID:  m.ContractID,
+			CoalesceKey: m.CoalesceKey,
+			TxHash:      m.TxHash,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+			Type:        m.Type,
+		}
+		if l.Msg, err = decodeMsg(cdc, m); err != nil {
+			l.DecodeError = err.Error()
+		}
+		if err := enc.Encode(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newCodec returns a codec which can encode the registered msg types as JSON, including any msgs wrapped by authz.MsgExec.
+func newCodec() codec.Codec {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authz.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	wasmtypes.RegisterInterfaces(registry)
+	injectivetypes.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// decodeMsg decodes the Raw msg by its type URL, and returns it as JSON.
+func decodeMsg(cdc codec.Codec, m adapters.Msg) (json.RawMessage, error) {
+	t, ok := adapters.GetMsgType(m.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown msg type %s", m.Type)
+	}
+	msg, err := t.Unmarshal(m.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", m.Type, err)
+	}
+	b, err := cdc.MarshalJSON(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s as JSON: %w", m.Type, err)
+	}
+	return b, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func parseIDs(args []string) ([]int64, error) {
+	if len(args) == 0 {
+		return nil, errors.New("missing msg ids")
+	}
+	ids := make([]int64, len(args))
+	for i, a := range args {
+		id, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid msg id %q: %w", a, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// report prints which of ids were updated, and which were skipped because of their state.
+func report(action string, ids, updated []int64) {
+	fmt.Printf("%s %d of %d msgs: %v\n", action, len(updated), len(ids), updated)
+	done := make(map[int64]bool, len(updated))
+	for _, id := range updated {
+		done[id] = true
+	}
+	var skipped []int64
+	for _, id := range ids {
+		if !done[id] {
+			skipped = append(skipped, id)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("skipped %v: not found on this chain, or not in a valid state\n", skipped)
+	}
+}
@@ -25,6 +25,7 @@ type ContractTransmitter struct {
 	jobID       string
 	contract    cosmosSDK.AccAddress
 	sender      cosmosSDK.AccAddress
+	granter     cosmosSDK.AccAddress
 	cfg         config.Config
 }
 
@@ -33,6 +34,7 @@ func NewContractTransmitter(
 	jobID string,
 	contract cosmosSDK.AccAddress,
 	sender cosmosSDK.AccAddress,
+	granter cosmosSDK.AccAddress,
 	msgEnqueuer adapters.MsgEnqueuer,
 	lggr logger.Logger,
 	cfg config.Config,
@@ -43,6 +45,7 @@ func NewContractTransmitter(
 		contract:    contract,
 		msgEnqueuer: msgEnqueuer,
 		sender:      sender,
+		granter:     granter,
 		lggr:        lggr,
 		cfg:         cfg,
 	}
@@ -70,15 +73,28 @@ func (ct *ContractTransmitter) Transmit(
 		return err
 	}
 	m := &wasmtypes.MsgExecuteContract{
-		Sender:   ct.sender.String(),
+		Sender:   ct.transmitterAddress().String(),
 		Contract: ct.contract.String(),
 		Msg:      msgBytes,
 		Funds:    cosmosSDK.Coins{},
 	}
-	_, err = ct.msgEnqueuer.Enqueue(ctx, ct.contract.String(), m)
+	var opts []adapters.EnqueueOption
+	if ct.granter != nil {
+		// The contract sees the granter as the transmitter, and sender only signs
+		opts = append(opts, adapters.WithGrantee(ct.sender.String()))
+	}
+	_, err = ct.msgEnqueuer.Enqueue(ctx, ct.contract.String(), m, opts...)
 	return err
 }
 
 func (ct *ContractTransmitter) FromAccount(ctx context.Context) (types.Account, error) {
-	return types.Account(ct.sender.String()), nil
+	return types.Account(ct.transmitterAddress().String()), nil
+}
+
+// transmitterAddress returns the account which the contract sees as the transmitter: the granter if set, otherwise sender.
+func (ct *ContractTransmitter) transmitterAddress() cosmosSDK.AccAddress {
+	if ct.granter != nil {
+		return ct.granter
+	}
+	return ct.sender
 }
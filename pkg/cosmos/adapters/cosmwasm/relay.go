@@ -3,6 +3,7 @@ package cosmwasm
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	cosmosSDK "github.com/cosmos/cosmos-sdk/types"
 
@@ -21,8 +22,9 @@ var _ relaytypes.ConfigProvider = &configProvider{}
 
 type configProvider struct {
 	utils.StartStopOnce
-	digester types.OffchainConfigDigester
-	lggr     logger.Logger
+	digester    types.OffchainConfigDigester
+	lggr        logger.Logger
+	relayConfig adapters.RelayConfig
 
 	tracker types.ContractConfigTracker
 
@@ -59,6 +61,7 @@ func NewConfigProvider(ctx context.Context, lggr logger.Logger, chain adapters.C
 		reader:        reader,
 		chain:         chain,
 		contractAddr:  contractAddr,
+		relayConfig:   relayConfig,
 	}, nil
 }
 
@@ -113,6 +116,30 @@ func NewMedianProvider(ctx context.Context, lggr logger.Logger, chain adapters.C
 	if err != nil {
 		return nil, err
 	}
+	var granterAddr cosmosSDK.AccAddress
+	if g := configProvider.relayConfig.Granter; g != "" {
+		granterAddr, err = cosmosSDK.AccAddressFromBech32(g)
+		if err != nil {
+			return nil, fmt.Errorf("invalid granter %s: %w", g, err)
+		}
+	}
+	var sendingKeys []string
+	for _, k := range configProvider.relayConfig.SendingKeys {
+		bech32Key, err := params.CreateBech32Address(k, configProvider.chain.Config().Bech32Prefix())
+		if err != nil {
+			return nil, fmt.Errorf("invalid sending key %s: %w", k, err)
+		}
+		keyAddr, err := cosmosSDK.AccAddressFromBech32(bech32Key)
+		if err != nil {
+			return nil, err
+		}
+		sendingKeys = append(sendingKeys, keyAddr.String())
+	}
+	// The contract still sees the same transmitter, whichever key sends the tx. Set before any transmissions,
+	// so that msgs left over from before a restart are sent from the pool too.
+	if err = configProvider.chain.TxManager().SetSendingKeys(senderAddr.String(), sendingKeys); err != nil {
+		return nil, err
+	}
 
 	return &medianProvider{
 		configProvider: configProvider,
@@ -123,6 +150,7 @@ func NewMedianProvider(ctx context.Context, lggr logger.Logger, chain adapters.C
 			rargs.ExternalJobID.String(),
 			configProvider.contractAddr,
 			senderAddr,
+			granterAddr,
 			configProvider.chain.TxManager(),
 			lggr,
 			configProvider.chain.Config(),
@@ -15,6 +15,12 @@ import (
 
 var _ types.ContractTransmitter = &CosmosModuleTransmitter{}
 
+func init() {
+	adapters.RegisterMsgTypes(
+		adapters.NewMsgType(func(m *chaintypes.MsgTransmit) string { return m.Transmitter }),
+	)
+}
+
 type CosmosModuleTransmitter struct {
 	lggr        logger.Logger
 	queryClient chaintypes.QueryClient
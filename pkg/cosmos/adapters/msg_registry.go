@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"fmt"
+	"sync"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	cosmosSDK "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// MsgType describes a type of message which can be enqueued with a TxManager.
+type MsgType struct {
+	// TypeURL is the type URL of the message, as returned by cosmosSDK.MsgTypeURL.
+	TypeURL string
+	// Unmarshal decodes a protobuf encoded message.
+	Unmarshal func(raw []byte) (cosmosSDK.Msg, error)
+	// Sender returns the bech32 address of the account which signs the message.
+	Sender func(msg cosmosSDK.Msg) (string, error)
+}
+
+// NewMsgType returns a MsgType for messages of type *T, with sender returning the signing account.
+func NewMsgType[T any, PT interface {
+	*T
+	cosmosSDK.Msg
+	Unmarshal([]byte) error
+}](sender func(PT) string) MsgType {
+	return MsgType{
+		TypeURL: cosmosSDK.MsgTypeURL(PT(new(T))),
+		Unmarshal: func(raw []byte) (cosmosSDK.Msg, error) {
+			msg := PT(new(T))
+			if err := msg.Unmarshal(raw); err != nil {
+				return nil, err
+			}
+			return msg, nil
+		},
+		Sender: func(msg cosmosSDK.Msg) (string, error) {
+			m, ok := msg.(PT)
+			if !ok {
+				return "", fmt.Errorf("expected %T but got %T", m, msg)
+			}
+			return sender(m), nil
+		},
+	}
+}
+
+var msgTypes = struct {
+	sync.RWMutex
+	m map[string]MsgType
+}{m: map[string]MsgType{}}
+
+func init() {
+	RegisterMsgTypes(
+		// The grantee signs on behalf of the granters of the wrapped msgs
+		NewMsgType(func(m *authz.MsgExec) string { return m.Grantee }),
+		NewMsgType(func(m *banktypes.MsgSend) string { return m.FromAddress }),
+		NewMsgType(func(m *wasmtypes.MsgExecuteContract) string { return m.Sender }),
+		NewMsgType(func(m *wasmtypes.MsgInstantiateContract) string { return m.Sender }),
+		NewMsgType(func(m *wasmtypes.MsgInstantiateContract2) string { return m.Sender }),
+		NewMsgType(func(m *wasmtypes.MsgMigrateContract) string { return m.Sender }),
+	)
+}
+
+// RegisterMsgTypes registers message types so that they can be enqueued with a TxManager.
+// Adapters should call this from init for any message types they enqueue.
+// Panics if a type URL is registered twice.
+func RegisterMsgTypes(types ...MsgType) {
+	msgTypes.Lock()
+	defer msgTypes.Unlock()
+	for _, t := range types {
+		if _, ok := msgTypes.m[t.TypeURL]; ok {
+			panic(fmt.Sprintf("msg type %s already registered", t.TypeURL))
+		}
+		msgTypes.m[t.TypeURL] = t
+	}
+}
+
+// GetMsgType returns the registered MsgType for typeURL.
+func GetMsgType(typeURL string) (MsgType, bool) {
+	msgTypes.RLock()
+	defer msgTypes.RUnlock()
+	t, ok := msgTypes.m[typeURL]
+	return t, ok
+}
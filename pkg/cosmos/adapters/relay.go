@@ -4,4 +4,11 @@ package adapters
 type RelayConfig struct {
 	ChainID  string `json:"chainID"`  // required
 	NodeName string `json:"nodeName"` // optional, defaults to a random node with ChainID
+	// SendingKeys are optional extra keys, in the same format as the TransmitterID, which transmit on behalf of
+	// the TransmitterID through authz. Each must have been granted authorization to execute the contract,
+	// by the Granter if set and otherwise by the TransmitterID.
+	SendingKeys []string `json:"sendingKeys"`
+	// Granter is an optional bech32 address which transmits through authz, with the TransmitterID as its grantee.
+	// Funds and payments stay with the Granter, so the node never needs to hold its key.
+	Granter string `json:"granter"`
 }
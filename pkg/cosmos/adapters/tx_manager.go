@@ -37,16 +37,100 @@ func (tms Msgs) GetIDs() []int64 {
 	return ids
 }
 
+// QueuePolicy determines what happens to a contract's Unstarted messages when a new message is enqueued.
+type QueuePolicy int
+
+const (
+	// ReplaceLatest cancels any Unstarted messages for the same contract ID, so only the latest is sent.
+	// This is the default, and suits OCR transmissions where only the latest report matters.
+	ReplaceLatest QueuePolicy = iota
+	// Append keeps any Unstarted messages for the same contract ID, so all messages are sent in order.
+	Append
+	// Coalesce cancels any Unstarted messages for the same contract ID with the same coalesce key.
+	Coalesce
+)
+
+// EnqueueOptions are the options for a single call to Enqueue.
+type EnqueueOptions struct {
+	Policy QueuePolicy
+	// CoalesceKey is required by the Coalesce policy.
+	CoalesceKey string
+	// Grantee executes the message on behalf of its signer, by wrapping it in an authz MsgExec.
+	Grantee string
+}
+
+// EnqueueOption configures EnqueueOptions.
+type EnqueueOption func(*EnqueueOptions)
+
+// WithAppend enqueues a message with the Append policy.
+func WithAppend() EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.Policy = Append
+	}
+}
+
+// WithCoalesceKey enqueues a message with the Coalesce policy, replacing Unstarted messages with the same key.
+func WithCoalesceKey(key string) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.Policy = Coalesce
+		o.CoalesceKey = key
+	}
+}
+
+// WithGrantee wraps the message in an authz MsgExec from grantee, so that the signer's key is never needed.
+// The grantee must have been granted authorization to execute the message by the signer.
+func WithGrantee(grantee string) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.Grantee = grantee
+	}
+}
+
+// NewEnqueueOptions returns EnqueueOptions with opts applied.
+func NewEnqueueOptions(opts ...EnqueueOption) EnqueueOptions {
+	var o EnqueueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 type MsgEnqueuer interface {
-	// Enqueue enqueues msg for broadcast and returns its id.
-	// Returns ErrMsgUnsupported for unsupported message types.
-	Enqueue(ctx context.Context, contractID string, msg cosmosSDK.Msg) (int64, error)
+	// Enqueue enqueues msg for broadcast and returns its id. By default, any Unstarted messages for
+	// the same contractID are cancelled, see QueuePolicy.
+	// Returns ErrMsgUnsupported for message types which have not been registered with RegisterMsgTypes.
+	Enqueue(ctx context.Context, contractID string, msg cosmosSDK.Msg, opts ...EnqueueOption) (int64, error)
+}
+
+// MsgEvent is a state transition of an enqueued message.
+type MsgEvent struct {
+	ID    int64
+	State db.State
+	// TxHash is the hash of the tx which includes the message, once Broadcasted.
+	TxHash *string
+	// Height is the height of the block which includes the tx, once Confirmed.
+	Height int64
+	// Reason is why the message was moved to State, if known.
+	Reason *db.Reason
+	// ErrorCode and ErrorLog describe the failure, if Errored and known.
+	ErrorCode *uint32
+	ErrorLog  *string
 }
 
 // TxManager manages txs composed of batches of queued messages.
 type TxManager interface {
 	MsgEnqueuer
 
+	// EnqueueAndWait enqueues msg like Enqueue, then blocks until it reaches a terminal state.
+	EnqueueAndWait(ctx context.Context, contractID string, msg cosmosSDK.Msg, opts ...EnqueueOption) (MsgEvent, error)
+	// Subscribe returns a channel of message state transitions, which are sent once committed, and a func to unsubscribe.
+	// Events are dropped if the channel is not drained promptly, so subscribers should fall back to GetMsgs.
+	Subscribe() (<-chan MsgEvent, func())
+	// SetSendingKeys replaces the extra keys which may send messages on behalf of signer, by wrapping them in an
+	// authz MsgExec. Each batch is sent from whichever key is least busy. Each key must have been granted
+	// authorization to execute the messages by signer, or by the granter of messages which signer executes.
+	// Keys are only kept in memory, so they must be set on every start, before any messages of signer are sent.
+	SetSendingKeys(signer string, keys []string) error
+
 	// GetMsgs returns any messages matching ids.
 	GetMsgs(ctx context.Context, ids ...int64) (Msgs, error)
 	// GasPrice returns the gas price in ucosm.
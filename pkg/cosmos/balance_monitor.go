@@ -0,0 +1,219 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+)
+
+var promBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cosmos_balance",
+	Help: "Balance of each key in the gas token",
+}, []string{"chainID", "account", "denom"})
+
+// maxBalanceReadFailures is how many polls in a row may fail to read the balances before reporting unhealthy,
+// so that a transient failure does not.
+const maxBalanceReadFailures = 3
+
+// balanceMonitor polls the balances of the keys in the keystore, and reports unhealthy when any are low,
+// or when they repeatedly can not be read.
+type balanceMonitor struct {
+	services.StateMachine
+	chainID  string
+	cfg      config.Config
+	lggr     logger.SugaredLogger
+	reader   func() (client.Reader, error)
+	accounts func(context.Context) ([]string, error)
+	gasPrice func() (sdk.DecCoin, error)
+
+	mu       sync.RWMutex
+	balances map[string]sdk.Coin
+	low      map[string]error // by account, as of the last time its balance was read and checked
+	failures int              // consecutive polls which failed to read the balances
+	readErr  error            // of the last poll
+
+	stop services.StopChan
+	wg   sync.WaitGroup
+}
+
+func newBalanceMonitor(chainID string, cfg config.Config, reader func() (client.Reader, error), accounts func(context.Context) ([]string, error),
+	gasPrice func() (sdk.DecCoin, error), lggr logger.Logger) *balanceMonitor {
+	return &balanceMonitor{
+		chainID:  chainID,
+		cfg:      cfg,
+		lggr:     logger.Sugared(logger.Named(lggr, "BalanceMonitor")),
+		reader:   reader,
+		accounts: accounts,
+		gasPrice: gasPrice,
+		balances: make(map[string]sdk.Coin),
+		low:      make(map[string]error),
+		stop:     make(services.StopChan),
+	}
+}
+
+func (b *balanceMonitor) Name() string { return b.lggr.Name() }
+
+func (b *balanceMonitor) Start(context.Context) error {
+	return b.StartOnce("BalanceMonitor", func() error {
+		b.wg.Add(1)
+		go b.run()
+		return nil
+	})
+}
+
+func (b *balanceMonitor) Close() error {
+	return b.StopOnce("BalanceMonitor", func() error {
+		close(b.stop)
+		b.wg.Wait()
+		return nil
+	})
+}
+
+func (b *balanceMonitor) HealthReport() map[string]error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return map[string]error{b.Name(): errors.Join(b.Healthy(), b.healthErr())}
+}
+
+// healthErr returns an error for each low balance, and for failing to read the balances maxBalanceReadFailures
+// times in a row. Callers must hold mu.
+func (b *balanceMonitor) healthErr() error {
+	accounts := make([]string, 0, len(b.low))
+	for account := range b.low {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	var err error
+	for _, account := range accounts {
+		err = errors.Join(err, b.low[account])
+	}
+	if b.failures >= maxBalanceReadFailures {
+		err = errors.Join(err, fmt.Errorf("failed to read balances %d times in a row: %w", b.failures, b.readErr))
+	}
+	return err
+}
+
+// Balances returns the balance of each key as of the last poll.
+func (b *balanceMonitor) Balances() map[string]sdk.Coin {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return maps.Clone(b.balances)
+}
+
+func (b *balanceMonitor) run() {
+	defer b.wg.Done()
+	ctx, cancel := b.stop.NewCtx()
+	defer cancel()
+	tick := time.After(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			b.check(ctx)
+			tick = time.After(utils.WithJitter(b.cfg.BalancePollPeriod()))
+		}
+	}
+}
+
+// check polls the balances, and records which are low. Accounts whose balance could not be read
+// keep their last known state.
+func (b *balanceMonitor) check(ctx context.Context) {
+	balances, low, err := b.poll(ctx)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for account, balance := range balances {
+		b.balances[account] = balance
+		if low == nil {
+			continue // not checked
+		}
+		if lowErr, ok := low[account]; ok {
+			b.low[account] = lowErr
+		} else {
+			delete(b.low, account)
+		}
+	}
+	if err != nil {
+		b.failures++
+		b.lggr.Warnw("unable to read balances, keeping the last known state", "err", err, "failures", b.failures)
+	} else {
+		b.failures = 0
+	}
+	b.readErr = err
+}
+
+// poll reads the balances, and returns an error for each which is low, or nil if they could not be checked.
+// The returned error is for the balances which could not be read.
+func (b *balanceMonitor) poll(ctx context.Context) (map[string]sdk.Coin, map[string]error, error) {
+	reader, err := b.reader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get client: %w", err)
+	}
+	accounts, err := b.accounts(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get accounts: %w", err)
+	}
+	minBalance, err := b.minBalance()
+	var low map[string]error
+	if err != nil {
+		// Still poll the balances
+		b.lggr.Warnw("unable to check for low balances", "err", err)
+	} else {
+		low = make(map[string]error)
+	}
+	denom := b.cfg.GasToken()
+	balances := make(map[string]sdk.Coin, len(accounts))
+	var merr error
+	for _, account := range accounts {
+		addr, err := sdk.AccAddressFromBech32(account)
+		if err != nil {
+			merr = errors.Join(merr, fmt.Errorf("invalid account %s: %w", account, err))
+			continue
+		}
+		balance, err := reader.Balance(ctx, addr, denom)
+		if err != nil {
+			merr = errors.Join(merr, fmt.Errorf("unable to get balance of %s: %w", account, err))
+			continue
+		}
+		balances[account] = *balance
+		amount, _ := new(big.Float).SetInt(balance.Amount.BigInt()).Float64()
+		promBalance.WithLabelValues(b.chainID, account, denom).Set(amount)
+		if minBalance != nil && sdk.NewDecFromInt(balance.Amount).LT(*minBalance) {
+			b.lggr.Warnw("balance is low", "account", account, "balance", balance, "min", minBalance)
+			low[account] = fmt.Errorf("balance of %s is low: %s is less than %s%s for %d transmissions",
+				account, balance, minBalance, denom, b.cfg.BalanceMinTransmissions())
+		}
+	}
+	return balances, low, merr
+}
+
+// minBalance returns the balance needed for BalanceMinTransmissions at the current gas price,
+// or nil if the check is disabled.
+func (b *balanceMonitor) minBalance() (*sdk.Dec, error) {
+	if b.cfg.BalanceMinTransmissions() == 0 || b.cfg.FeeGranter() != "" {
+		return nil, nil
+	}
+	gasPrice, err := b.gasPrice()
+	if err != nil {
+		return nil, err
+	}
+	gas := sdk.NewDec(b.cfg.BalanceMinTransmissions()).MulInt64(b.cfg.BalanceTransmissionGas())
+	minBalance := gasPrice.Amount.Mul(gas)
+	return &minBalance, nil
+}
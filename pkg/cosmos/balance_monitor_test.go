@@ -0,0 +1,74 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client/mocks"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+)
+
+func TestBalanceMonitor(t *testing.T) {
+	ctx := tests.Context(t)
+	minTransmissions, transmissionGas := int64(10), int64(1000)
+	cfg := &config.TOMLConfig{Chain: config.Chain{
+		BalanceMinTransmissions: &minTransmissions,
+		BalanceTransmissionGas:  &transmissionGas,
+	}}
+	cfg.SetDefaults()
+	denom := cfg.GasToken()
+	rich := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	poor := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	tc := mocks.NewReaderWriter(t)
+	tc.On("Balance", mock.Anything, rich, denom).Return(&sdk.Coin{Denom: denom, Amount: sdk.NewInt(100)}, nil)
+	tc.On("Balance", mock.Anything, poor, denom).Return(&sdk.Coin{Denom: denom, Amount: sdk.NewInt(99)}, nil).Once()
+	gasPrice := sdk.NewDecCoinFromDec(denom, sdk.MustNewDecFromStr("0.01")) // 100 for 10 transmissions of 1000 gas
+	bm := newBalanceMonitor("chain", cfg, func() (client.Reader, error) { return tc, nil }, func(context.Context) ([]string, error) {
+		return []string{rich.String(), poor.String()}, nil
+	}, func() (sdk.DecCoin, error) { return gasPrice, nil }, logger.Test(t))
+
+	bm.check(ctx)
+	balances := bm.Balances()
+	assert.Equal(t, "100", balances[rich.String()].Amount.String())
+	assert.Equal(t, "99", balances[poor.String()].Amount.String())
+	err := bm.HealthReport()[bm.Name()]
+	require.ErrorContains(t, err, poor.String())
+	assert.NotContains(t, err.Error(), rich.String())
+
+	// Still low while the balance can not be read, and only unhealthy for failing to read it after repeated failures
+	tc.On("Balance", mock.Anything, poor, denom).Return(nil, errors.New("unavailable")).Times(maxBalanceReadFailures)
+	gasPrice = sdk.NewDecCoinFromDec(denom, sdk.MustNewDecFromStr("0.001"))
+	for i := 1; i <= maxBalanceReadFailures; i++ {
+		bm.check(ctx)
+		err = bm.healthErr()
+		require.ErrorContains(t, err, "is low")
+		if i < maxBalanceReadFailures {
+			assert.NotContains(t, err.Error(), "unavailable")
+		} else {
+			assert.ErrorContains(t, err, "unavailable")
+		}
+	}
+
+	// Cheaper gas
+	tc.On("Balance", mock.Anything, poor, denom).Return(&sdk.Coin{Denom: denom, Amount: sdk.NewInt(99)}, nil).Once()
+	bm.check(ctx)
+	assert.NoError(t, bm.healthErr())
+
+	// A transient failure keeps the last known state
+	tc.On("Balance", mock.Anything, poor, denom).Return(nil, errors.New("unavailable")).Once()
+	bm.check(ctx)
+	assert.NoError(t, bm.healthErr())
+	assert.Equal(t, "99", bm.Balances()[poor.String()].Amount.String())
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -80,6 +82,18 @@ type chain struct {
 	cfg  *config.TOMLConfig
 	txm  *txm.Txm
 	lggr logger.Logger
+	// percentileGPE is set if GasPriceBlockHistory is configured
+	percentileGPE *client.PercentileGasPriceEstimator
+	balances      *balanceMonitor
+
+	// txmClients are the clients of the Txm by node name, which are reused until the node changes.
+	txmClientsMu sync.Mutex
+	txmClients   map[string]txmClient
+}
+
+type txmClient struct {
+	node   db.Node
+	client *client.Client
 }
 
 func newChain(id string, cfg *config.TOMLConfig, ds sqlutil.DataSource, ks loop.Keystore, lggr logger.Logger) (*chain, error) {
@@ -90,16 +104,40 @@ func newChain(id string, cfg *config.TOMLConfig, ds sqlutil.DataSource, ks loop.
 		lggr: logger.Named(lggr, "Chain"),
 	}
 	tc := func() (client.ReaderWriter, error) {
-		return ch.getClient("")
+		return ch.getTxmClient()
 	}
-	gpe := client.NewMustGasPriceEstimator([]client.GasPricesEstimator{
+	var estimators []client.GasPricesEstimator
+	if cfg.GasPriceBlockHistory() > 0 {
+		ch.percentileGPE = client.NewPercentileGasPriceEstimator(func() (client.BlockReader, error) {
+			return ch.getClient("")
+		}, cfg.GasToken(), cfg.GasPriceBlockHistory(), cfg.GasPricePercentile(), cfg.BlockRate(), lggr)
+		estimators = append(estimators, ch.percentileGPE)
+	}
+	estimators = append(estimators,
+		client.NewFeeMarketGasPriceEstimator(func() (client.GasPricesReader, error) {
+			return ch.getClient("")
+		}, cfg.GasToken(), cfg.BlockRate(), lggr),
 		client.NewClosureGasPriceEstimator(func() (map[string]sdk.DecCoin, error) {
 			return map[string]sdk.DecCoin{
 				cfg.GasToken(): sdk.NewDecCoinFromDec(cfg.GasToken(), cfg.FallbackGasPrice()),
 			}, nil
 		}),
-	}, lggr)
-	ch.txm = txm.NewTxm(ds, tc, *gpe, ch.id, cfg, ks, lggr)
+	)
+	var maxGasPrices sdk.DecCoins
+	if cfg.MaxGasPrice().IsPositive() {
+		maxGasPrices = sdk.NewDecCoins(sdk.NewDecCoinFromDec(cfg.GasToken(), cfg.MaxGasPrice()))
+	}
+	gpe := client.NewComposedGasPriceEstimator(estimators, maxGasPrices, lggr)
+	ctx, cancel := context.WithTimeout(context.Background(), client.DefaultTimeout)
+	defer cancel()
+	var err error
+	ch.txm, err = txm.NewTxm(ctx, ds, tc, gpe, ch.id, cfg, ks, lggr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txm: %w", err)
+	}
+	ch.balances = newBalanceMonitor(ch.id, cfg, func() (client.Reader, error) {
+		return ch.getClient("")
+	}, ch.txm.Accounts, ch.txm.GasPrice, lggr)
 
 	return &ch, nil
 }
@@ -129,21 +167,15 @@ func (c *chain) Reader(name string) (client.Reader, error) {
 }
 
 // getClient returns a client, optionally requiring a specific node by name.
+// SendOnly nodes are never picked when no name is given.
 func (c *chain) getClient(name string) (client.ReaderWriter, error) {
 	var node db.Node
 	if name == "" { // Any node
-		nodes, err := c.cfg.ListNodes()
-		if err != nil {
-			return nil, fmt.Errorf("failed to list nodes: %w", err)
-		}
-		if len(nodes) == 0 {
-			return nil, errors.New("no nodes available")
-		}
-		nodeIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(nodes))))
+		var err error
+		node, err = c.pickNode()
 		if err != nil {
-			return nil, fmt.Errorf("could not generate a random node index: %w", err)
+			return nil, err
 		}
-		node = nodes[nodeIndex.Int64()]
 	} else { // Named node
 		var err error
 		node, err = c.cfg.GetNode(name)
@@ -154,7 +186,28 @@ func (c *chain) getClient(name string) (client.ReaderWriter, error) {
 			return nil, fmt.Errorf("failed to create client for chain %s with node %s: wrong chain id %s", c.id, name, node.CosmosChainID)
 		}
 	}
-	client, err := client.NewClient(c.id, node.TendermintURL, defaultRequestTimeout, logger.Named(c.lggr, "Client."+name))
+	return c.newClient(node)
+}
+
+// pickNode returns a random node which is not SendOnly.
+func (c *chain) pickNode() (db.Node, error) {
+	nodes, err := c.cfg.ListNodes()
+	if err != nil {
+		return db.Node{}, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodes = slices.DeleteFunc(nodes, func(n db.Node) bool { return n.SendOnly })
+	if len(nodes) == 0 {
+		return db.Node{}, errors.New("no nodes available")
+	}
+	nodeIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(nodes))))
+	if err != nil {
+		return db.Node{}, fmt.Errorf("could not generate a random node index: %w", err)
+	}
+	return nodes[nodeIndex.Int64()], nil
+}
+
+func (c *chain) newClient(node db.Node) (*client.Client, error) {
+	client, err := client.NewClient(c.id, node.TendermintURL, defaultRequestTimeout, logger.Named(c.lggr, "Client."+node.Name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
@@ -162,18 +215,112 @@ func (c *chain) getClient(name string) (client.ReaderWriter, error) {
 	return client, nil
 }
 
+// getTxmClient returns a client for the Txm, which broadcasts txs through a random node plus any SendOnly nodes,
+// or through all nodes if BroadcastToAllNodes is set.
+// The clients of each node are cached, since the Txm gets a client for every batch and confirmation.
+func (c *chain) getTxmClient() (client.ReaderWriter, error) {
+	nodes, err := c.cfg.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	node, err := c.pickNode()
+	if err != nil {
+		return nil, err
+	}
+
+	c.txmClientsMu.Lock()
+	defer c.txmClientsMu.Unlock()
+	c.pruneTxmClients(nodes)
+	rw, err := c.txmClient(node)
+	if err != nil {
+		return nil, err
+	}
+	broadcasters := map[string]client.Broadcaster{node.Name: rw}
+	for _, n := range nodes {
+		if n.Name == node.Name || !(n.SendOnly || c.cfg.BroadcastToAllNodes()) {
+			continue
+		}
+		b, err := c.txmClient(n)
+		if err != nil {
+			// Broadcast through the rest
+			c.lggr.Warnw("Unable to create client to broadcast through", "name", n.Name, "err", err)
+			continue
+		}
+		broadcasters[n.Name] = b
+	}
+	if len(broadcasters) == 1 {
+		return rw, nil
+	}
+	return client.NewMultiNodeClient(rw, broadcasters, c.lggr), nil
+}
+
+// txmClient returns the cached client for node, creating it if the node is new or has changed.
+// Callers must hold txmClientsMu.
+func (c *chain) txmClient(node db.Node) (*client.Client, error) {
+	cached, ok := c.txmClients[node.Name]
+	if ok && cached.node == node {
+		return cached.client, nil
+	}
+	cl, err := c.newClient(node)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		c.closeTxmClient(node.Name, cached)
+	}
+	if c.txmClients == nil {
+		c.txmClients = make(map[string]txmClient)
+	}
+	c.txmClients[node.Name] = txmClient{node: node, client: cl}
+	return cl, nil
+}
+
+// pruneTxmClients closes and drops the cached clients of nodes which are no longer configured.
+// Callers must hold txmClientsMu.
+func (c *chain) pruneTxmClients(nodes []db.Node) {
+	for name := range c.txmClients {
+		if !slices.ContainsFunc(nodes, func(n db.Node) bool { return n.Name == name }) {
+			c.closeTxmClient(name, c.txmClients[name])
+			delete(c.txmClients, name)
+		}
+	}
+}
+
+// closeTxmClient closes a cached client which is no longer used, so that its websocket does not leak.
+// Txs still being confirmed through it fall back to polling.
+func (c *chain) closeTxmClient(name string, cached txmClient) {
+	if err := cached.client.Close(); err != nil {
+		c.lggr.Warnw("Unable to close client", "name", name, "err", err)
+	}
+}
+
 // Start starts cosmos chain.
 func (c *chain) Start(ctx context.Context) error {
 	return c.StartOnce("Chain", func() error {
 		c.lggr.Debug("Starting")
-		return c.txm.Start(ctx)
+		if c.percentileGPE != nil {
+			if err := c.percentileGPE.Start(ctx); err != nil {
+				return err
+			}
+		}
+		if err := c.txm.Start(ctx); err != nil {
+			return err
+		}
+		return c.balances.Start(ctx)
 	})
 }
 
 func (c *chain) Close() error {
 	return c.StopOnce("Chain", func() error {
 		c.lggr.Debug("Stopping")
-		return c.txm.Close()
+		err := errors.Join(c.balances.Close(), c.txm.Close())
+		if c.percentileGPE != nil {
+			err = errors.Join(err, c.percentileGPE.Close())
+		}
+		c.txmClientsMu.Lock()
+		c.pruneTxmClients(nil)
+		c.txmClientsMu.Unlock()
+		return err
 	})
 }
 
@@ -181,12 +328,17 @@ func (c *chain) Ready() error {
 	return errors.Join(
 		c.StateMachine.Ready(),
 		c.txm.Ready(),
+		c.balances.Ready(),
 	)
 }
 
 func (c *chain) HealthReport() map[string]error {
 	m := map[string]error{c.Name(): c.Healthy()}
 	services.CopyHealth(m, c.txm.HealthReport())
+	services.CopyHealth(m, c.balances.HealthReport())
+	if c.percentileGPE != nil {
+		services.CopyHealth(m, c.percentileGPE.HealthReport())
+	}
 	return m
 }
 
@@ -256,7 +408,7 @@ func (c *chain) Transact(ctx context.Context, from, to string, amount *big.Int,
 	}
 
 	sendMsg := bank.NewMsgSend(fromAcc, toAcc, sdk.Coins{coin})
-	_, err = txm.Enqueue(ctx, "", sendMsg)
+	_, err = txm.Enqueue(ctx, "", sendMsg, adapters.WithAppend())
 	if err != nil {
 		return fmt.Errorf("failed to enqueue tx: %w", err)
 	}
@@ -0,0 +1,80 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commoncfg "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+)
+
+func TestChain_getTxmClient(t *testing.T) {
+	node := func(name, url string, sendOnly bool) *config.Node {
+		return &config.Node{Name: &name, TendermintURL: commoncfg.MustParseURL(url), SendOnly: &sendOnly}
+	}
+	newTestChain := func(t *testing.T, broadcastToAll bool) *chain {
+		chainID := "test-chain"
+		cfg := &config.TOMLConfig{
+			ChainID: &chainID,
+			Chain:   config.Chain{BroadcastToAllNodes: &broadcastToAll},
+			Nodes: config.Nodes{
+				node("primary-1", "http://primary-1:26657", false),
+				node("primary-2", "http://primary-2:26657", false),
+				node("send-only", "http://send-only:26657", true),
+			},
+		}
+		cfg.SetDefaults()
+		require.NoError(t, cfg.ValidateConfig())
+		return &chain{id: chainID, cfg: cfg, lggr: logger.Test(t)}
+	}
+
+	t.Run("send only", func(t *testing.T) {
+		ch := newTestChain(t, false)
+		for i := 0; i < 10; i++ {
+			n, err := ch.pickNode()
+			require.NoError(t, err)
+			assert.NotEqual(t, "send-only", n.Name)
+		}
+		tc, err := ch.getTxmClient()
+		require.NoError(t, err)
+		mc, ok := tc.(*client.MultiNodeClient)
+		require.True(t, ok)
+		assert.Equal(t, 2, mc.Broadcasters())
+	})
+
+	t.Run("all nodes", func(t *testing.T) {
+		ch := newTestChain(t, true)
+		tc, err := ch.getTxmClient()
+		require.NoError(t, err)
+		mc, ok := tc.(*client.MultiNodeClient)
+		require.True(t, ok)
+		assert.Equal(t, 3, mc.Broadcasters())
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ch := newTestChain(t, true)
+		_, err := ch.getTxmClient()
+		require.NoError(t, err)
+		require.Len(t, ch.txmClients, 3)
+		sendOnly := ch.txmClients["send-only"].client
+
+		_, err = ch.getTxmClient()
+		require.NoError(t, err)
+		assert.Same(t, sendOnly, ch.txmClients["send-only"].client, "reused")
+
+		// Changing the nodes rebuilds their clients
+		ch.cfg.Nodes = config.Nodes{
+			node("primary-1", "http://primary-1:26657", false),
+			node("send-only", "http://send-only-2:26657", true),
+		}
+		_, err = ch.getTxmClient()
+		require.NoError(t, err)
+		require.Len(t, ch.txmClients, 2)
+		assert.NotSame(t, sendOnly, ch.txmClients["send-only"].client)
+	})
+}
@@ -2,13 +2,17 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
-	"regexp"
-	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
@@ -17,7 +21,9 @@ import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
 	cosmosclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	tmtypes "github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
@@ -28,6 +34,7 @@ import (
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 )
 
 //go:generate mockery --name ReaderWriter --output ./mocks/
@@ -42,13 +49,29 @@ type Reader interface {
 	ContractState(ctx context.Context, contractAddress sdk.AccAddress, queryMsg []byte) ([]byte, error)
 	TxsEvents(ctx context.Context, events []string, paginationParams *query.PageRequest) (*txtypes.GetTxsEventResponse, error)
 	Tx(ctx context.Context, hash string) (*txtypes.GetTxResponse, error)
-	LatestBlock(context.Context) (*tmtypes.GetLatestBlockResponse, error)
-	BlockByHeight(ctx context.Context, height int64) (*tmtypes.GetBlockByHeightResponse, error)
+	// SubscribeTx subscribes to the tx with hash being included in a block, through the node's websocket.
+	// The returned channel receives once the tx is included, and is closed when the subscription ends,
+	// which happens once ctx is done.
+	SubscribeTx(ctx context.Context, hash string) (<-chan struct{}, error)
+	BlockReader
+	// ConsensusParams returns the chain's current consensus params, e.g. the block max gas and max bytes.
+	ConsensusParams(ctx context.Context) (*cmttypes.ConsensusParams, error)
 	Balance(ctx context.Context, addr sdk.AccAddress, denom string) (*sdk.Coin, error)
+	FeeAllowance(ctx context.Context, granter, grantee sdk.AccAddress) (feegrant.FeeAllowanceI, error)
+	GasPricesReader
 	// TODO: escape hatch for injective client
 	Context() *cosmosclient.Context
 }
 
+// GasPricesReader provides methods for reading the current gas prices from a cosmos chain.
+type GasPricesReader interface {
+	// FeeMarketGasPrices returns the current gas prices from the chain's fee market module.
+	// Returns an error if the chain does not have a fee market module.
+	FeeMarketGasPrices(ctx context.Context) (sdk.DecCoins, error)
+	// MinGasPrices returns the minimum gas prices accepted by the node.
+	MinGasPrices(ctx context.Context) (sdk.DecCoins, error)
+}
+
 // Writer provides methods for writing to a cosmos chain.
 // Assumes all msgs are for the same from address.
 // We may want to support multiple from addresses + signers if a use case arises.
@@ -59,7 +82,8 @@ type Writer interface {
 	Simulate(ctx context.Context, txBytes []byte) (*txtypes.SimulateResponse, error)
 	BatchSimulateUnsigned(ctx context.Context, msgs SimMsgs, sequence uint64) (*BatchSimResults, error)
 	SimulateUnsigned(ctx context.Context, msgs []sdk.Msg, sequence uint64) (*txtypes.SimulateResponse, error)
-	CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice sdk.DecCoin, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error)
+	// CreateAndSign creates and signs a tx. If feeGranter is not nil, the fee is paid from its allowance for the signer.
+	CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice sdk.DecCoin, feeGranter sdk.AccAddress, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error)
 }
 
 var _ ReaderWriter = (*Client)(nil)
@@ -85,11 +109,15 @@ const (
 // Client is a cosmos client
 type Client struct {
 	chainID                 string
+	rpcClient               *rpchttp.HTTP
+	wsMu                    sync.Mutex // guards starting the websocket of rpcClient
 	clientCtx               cosmosclient.Context
 	cosmosServiceClient     txtypes.ServiceClient
 	authClient              authtypes.QueryClient
 	wasmClient              wasmtypes.QueryClient
 	bankClient              banktypes.QueryClient
+	feegrantClient          feegrant.QueryClient
+	nodeClient              node.ServiceClient
 	tendermintServiceClient tmtypes.ServiceClient
 	log                     logger.Logger
 }
@@ -127,14 +155,19 @@ func NewClient(chainID string,
 	wasmClient := wasmtypes.NewQueryClient(clientCtx)
 	tendermintServiceClient := tmtypes.NewServiceClient(clientCtx)
 	bankClient := banktypes.NewQueryClient(clientCtx)
+	feegrantClient := feegrant.NewQueryClient(clientCtx)
+	nodeClient := node.NewServiceClient(clientCtx)
 
 	return &Client{
 		chainID:                 chainID,
+		rpcClient:               tmClient,
 		cosmosServiceClient:     cosmosServiceClient,
 		authClient:              authClient,
 		wasmClient:              wasmClient,
 		tendermintServiceClient: tendermintServiceClient,
 		bankClient:              bankClient,
+		feegrantClient:          feegrantClient,
+		nodeClient:              nodeClient,
 		clientCtx:               clientCtx,
 		log:                     lggr,
 	}, nil
@@ -185,11 +218,14 @@ func (c *Client) TxsEvents(ctx context.Context, events []string, paginationParam
 	return e, err
 }
 
-// Tx gets a tx by hash
+// Tx gets a tx by hash. Returns ErrTxNotFound if the node does not know of it.
 func (c *Client) Tx(ctx context.Context, hash string) (*txtypes.GetTxResponse, error) {
 	e, err := c.cosmosServiceClient.GetTx(ctx, &txtypes.GetTxRequest{
 		Hash: hash,
 	})
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", ErrTxNotFound, hash)
+	}
 	return e, err
 }
 
@@ -198,13 +234,22 @@ func (c *Client) LatestBlock(ctx context.Context) (*tmtypes.GetLatestBlockRespon
 	return c.tendermintServiceClient.GetLatestBlock(ctx, &tmtypes.GetLatestBlockRequest{})
 }
 
+// ConsensusParams gets the consensus params at the latest height
+func (c *Client) ConsensusParams(ctx context.Context) (*cmttypes.ConsensusParams, error) {
+	r, err := c.rpcClient.ConsensusParams(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &r.ConsensusParams, nil
+}
+
 // BlockByHeight gets a block by height
 func (c *Client) BlockByHeight(ctx context.Context, height int64) (*tmtypes.GetBlockByHeightResponse, error) {
 	return c.tendermintServiceClient.GetBlockByHeight(ctx, &tmtypes.GetBlockByHeightRequest{Height: height})
 }
 
 // CreateAndSign creates and signs a transaction
-func (c *Client) CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice sdk.DecCoin, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error) {
+func (c *Client) CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice sdk.DecCoin, feeGranter sdk.AccAddress, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error) {
 	// https://github.com/cosmos/cosmos-sdk/blob/a785bf5af602525cf7a5c5ea097056597e2eb7ef/client/tx/tx.go#L63-L117
 	// https://docs.cosmos.network/main/run-node/txs#signing-a-transaction-1
 	txConfig := params.ClientTxConfig()
@@ -217,6 +262,9 @@ func (c *Client) CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64,
 	txBuilder.SetGasLimit(gasLimitBuffered)
 	gasFee := sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.MulInt64(int64(gasLimitBuffered)).Ceil().RoundInt())
 	txBuilder.SetFeeAmount(sdk.NewCoins(gasFee))
+	if feeGranter != nil {
+		txBuilder.SetFeeGranter(feeGranter)
+	}
 	// 0 timeout height means unset.
 	txBuilder.SetTimeoutHeight(timeoutHeight)
 
@@ -281,6 +329,12 @@ func (c *Client) CreateAndSign(msgs []sdk.Msg, account uint64, sequence uint64,
 type SimMsg struct {
 	ID  int64
 	Msg sdk.Msg
+	// Err is the simulation error, set for failed msgs in BatchSimResults.
+	Err error
+	// GasUsed is the gas used by simulating the msg in a tx on its own, or 0 if it was not simulated on its own.
+	// It includes the gas used by the tx itself, e.g. to verify the signature, so it overestimates the gas
+	// used by the msg in a batch.
+	GasUsed uint64
 }
 
 // SimMsgs is a slice of SimMsg
@@ -310,24 +364,6 @@ type BatchSimResults struct {
 	Succeeded SimMsgs
 }
 
-var failedMsgIndexRe = regexp.MustCompile(`^.*failed to execute message; message index: (?P<Index>\d+):.*$`)
-
-func (c *Client) failedMsgIndex(err error) (bool, int) {
-	if err == nil {
-		return false, 0
-	}
-
-	m := failedMsgIndexRe.FindStringSubmatch(err.Error())
-	if len(m) != 2 {
-		return false, 0
-	}
-	index, err := strconv.ParseInt(m[1], 10, 32)
-	if err != nil {
-		return false, 0
-	}
-	return true, int(index)
-}
-
 // BatchSimulateUnsigned simulates a group of msgs.
 // Assumes at least one msg is present.
 // If we fail to simulate the batch, remove the offending tx
@@ -336,22 +372,31 @@ func (c *Client) failedMsgIndex(err error) (bool, int) {
 // Note that the error from simulating indicates the first
 // msg in the slice which failed (it simply loops over the msgs
 // and simulates them one by one, breaking at the first failure).
+// A failure inside an authz MsgExec is reported at the index of the MsgExec, since
+// the tx level index is the first in the error.
+// The GasUsed of a succeeded msg is set if it happened to be simulated on its own.
 func (c *Client) BatchSimulateUnsigned(ctx context.Context, msgs SimMsgs, sequence uint64) (*BatchSimResults, error) {
 	var succeeded []SimMsg
 	var failed []SimMsg
 	toSim := msgs
 	for {
-		_, err := c.SimulateUnsigned(ctx, toSim.GetMsgs(), sequence)
-		containsFailure, failureIndex := c.failedMsgIndex(err)
-		if err != nil && !containsFailure {
-			return nil, err
-		}
-		if !containsFailure {
+		s, err := c.SimulateUnsigned(ctx, toSim.GetMsgs(), sequence)
+		if err == nil {
 			// we're done they all succeeded
+			if len(toSim) == 1 {
+				toSim[0].GasUsed = s.GasInfo.GasUsed
+			}
 			succeeded = append(succeeded, toSim...)
 			break
 		}
-		failed = append(failed, toSim[failureIndex])
+		var simErr *SimulationError
+		if !errors.As(err, &simErr) || simErr.MsgIndex < 0 || simErr.MsgIndex >= len(toSim) {
+			return nil, err
+		}
+		failureIndex := simErr.MsgIndex
+		failure := toSim[failureIndex]
+		failure.Err = err
+		failed = append(failed, failure)
 		succeeded = append(succeeded, toSim[:failureIndex]...)
 		// remove offending msg and retry
 		if failureIndex == len(toSim)-1 {
@@ -393,18 +438,97 @@ func (c *Client) SimulateUnsigned(ctx context.Context, msgs []sdk.Msg, sequence
 	if err != nil {
 		return nil, err
 	}
-	s, err := c.cosmosServiceClient.Simulate(ctx, &txtypes.SimulateRequest{
-		TxBytes: txBytes,
-	})
-	return s, err
+	return c.simulate(ctx, txBytes)
+}
+
+// txSubscriber identifies our websocket subscriptions to the node.
+const txSubscriber = "chainlink-cosmos"
+
+// startWebsocket connects the websocket of the rpc client, unless it is connected already.
+// It is connected on first use, and shared by all subscriptions. A failed connection is retried on the next call.
+func (c *Client) startWebsocket() error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if c.rpcClient.IsRunning() {
+		return nil
+	}
+	return c.rpcClient.Start()
+}
+
+// Close disconnects the websocket of the rpc client, if it was connected. Subscriptions end, and
+// later ones fail, but the client can still be used otherwise.
+func (c *Client) Close() error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if !c.rpcClient.IsRunning() {
+		return nil
+	}
+	return c.rpcClient.Stop()
+}
+
+// SubscribeTx subscribes to the tx with hash being included in a block, through the node's websocket.
+// All subscriptions share the websocket connection of the client, and are unsubscribed once ctx is done.
+func (c *Client) SubscribeTx(ctx context.Context, hash string) (<-chan struct{}, error) {
+	if err := c.startWebsocket(); err != nil {
+		return nil, fmt.Errorf("failed to connect to websocket: %w", err)
+	}
+	query := fmt.Sprintf("%s='%s' AND %s='%s'", cmttypes.EventTypeKey, cmttypes.EventTx, cmttypes.TxHashKey, hash)
+	events, err := c.rpcClient.Subscribe(ctx, txSubscriber, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to tx %s: %w", hash, err)
+	}
+	included := make(chan struct{}, 1)
+	go func() {
+		defer close(included)
+		defer func() {
+			// ctx may be done, so unsubscribe with a new one
+			unsubscribeCtx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+			defer cancel()
+			if err := c.rpcClient.Unsubscribe(unsubscribeCtx, txSubscriber, query); err != nil {
+				c.log.Debugw("failed to unsubscribe from tx", "err", err, "hash", hash)
+			}
+		}()
+		select {
+		case <-ctx.Done():
+		case _, ok := <-events:
+			if ok {
+				included <- struct{}{}
+			}
+		}
+	}()
+	return included, nil
 }
 
 // Simulate simulates a signed transaction
 func (c *Client) Simulate(ctx context.Context, txBytes []byte) (*txtypes.SimulateResponse, error) {
-	s, err := c.cosmosServiceClient.Simulate(ctx, &txtypes.SimulateRequest{
-		TxBytes: txBytes,
-	})
-	return s, err
+	return c.simulate(ctx, txBytes)
+}
+
+// simulatePath is the ABCI query path of the app's own simulation. The gRPC Simulate service is queried
+// over ABCI too, but drops the codespace and code of a failure, which this path keeps.
+const simulatePath = "/app/simulate"
+
+// simulate simulates txBytes, returning a SimulationError with the ABCI error of a failure.
+// Only the GasInfo of the response is set, since its msg responses may be of types unknown to the codec.
+func (c *Client) simulate(ctx context.Context, txBytes []byte) (*txtypes.SimulateResponse, error) {
+	res, err := c.rpcClient.ABCIQuery(ctx, simulatePath, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, newSimulationError(&TxError{Codespace: res.Response.Codespace, Code: res.Response.Code, Log: res.Response.Log})
+	}
+	var simRes struct {
+		GasInfo json.RawMessage `json:"gas_info"`
+	}
+	if err := json.Unmarshal(res.Response.Value, &simRes); err != nil {
+		return nil, fmt.Errorf("failed to decode simulation response: %w", err)
+	}
+	var gasInfo sdk.GasInfo
+	if err := c.clientCtx.Codec.UnmarshalJSON(simRes.GasInfo, &gasInfo); err != nil {
+		return nil, fmt.Errorf("failed to decode simulation gas info: %w", err)
+	}
+	return &txtypes.SimulateResponse{GasInfo: &gasInfo}, nil
 }
 
 // Broadcast broadcasts a tx
@@ -420,7 +544,7 @@ func (c *Client) Broadcast(ctx context.Context, txBytes []byte, mode txtypes.Bro
 		return nil, fmt.Errorf("got nil tx response")
 	}
 	if res.TxResponse.Code != 0 {
-		return res, fmt.Errorf("tx failed with error code: %d, resp %v", res.TxResponse.Code, res.TxResponse)
+		return res, &TxError{Codespace: res.TxResponse.Codespace, Code: res.TxResponse.Code, Log: res.TxResponse.RawLog}
 	}
 	return res, err
 }
@@ -432,7 +556,7 @@ func (c *Client) SignAndBroadcast(ctx context.Context, msgs []sdk.Msg, account u
 		return nil, err
 	}
 	// TODO: replace with BroadcastTx()?
-	txBytes, err := c.CreateAndSign(msgs, account, sequence, sim.GasInfo.GasUsed, DefaultGasLimitMultiplier, gasPrice, signer, 0)
+	txBytes, err := c.CreateAndSign(msgs, account, sequence, sim.GasInfo.GasUsed, DefaultGasLimitMultiplier, gasPrice, nil, signer, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -447,3 +571,72 @@ func (c *Client) Balance(ctx context.Context, addr sdk.AccAddress, denom string)
 	}
 	return b.Balance, nil
 }
+
+// FeeAllowance returns the fee allowance granted to grantee by granter.
+func (c *Client) FeeAllowance(ctx context.Context, granter, grantee sdk.AccAddress) (feegrant.FeeAllowanceI, error) {
+	r, err := c.feegrantClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{Granter: granter.String(), Grantee: grantee.String()})
+	if err != nil {
+		return nil, err
+	}
+	if r.Allowance == nil {
+		return nil, fmt.Errorf("no allowance from %s to %s", granter, grantee)
+	}
+	return r.Allowance.GetGrant()
+}
+
+// feeMarketGasPricesPath is the query for the gas prices of an EIP-1559 style fee market module,
+// see https://github.com/skip-mev/feemarket
+const feeMarketGasPricesPath = "/feemarket.feemarket.v1.Query/GasPrices"
+
+// FeeMarketGasPrices returns the current gas prices from the chain's fee market module.
+func (c *Client) FeeMarketGasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	// The module's types are not a dependency, so query and decode the response directly
+	r, err := c.clientCtx.Client.ABCIQuery(ctx, feeMarketGasPricesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Response.IsOK() {
+		return nil, fmt.Errorf("failed to query fee market gas prices: code %d: %s", r.Response.Code, r.Response.Log)
+	}
+	return decodeFeeMarketGasPrices(r.Response.Value)
+}
+
+// decodeFeeMarketGasPrices decodes a GasPricesResponse, which has a single field of repeated DecCoins.
+func decodeFeeMarketGasPrices(raw []byte) (sdk.DecCoins, error) {
+	var prices sdk.DecCoins
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return nil, fmt.Errorf("invalid gas prices response: %w", protowire.ParseError(n))
+		}
+		raw = raw[n:]
+		if num != 1 || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, raw)
+			if n < 0 {
+				return nil, fmt.Errorf("invalid gas prices response: %w", protowire.ParseError(n))
+			}
+			raw = raw[n:]
+			continue
+		}
+		b, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return nil, fmt.Errorf("invalid gas prices response: %w", protowire.ParseError(n))
+		}
+		raw = raw[n:]
+		var price sdk.DecCoin
+		if err := price.Unmarshal(b); err != nil {
+			return nil, fmt.Errorf("invalid gas price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+	return prices.Sort(), nil
+}
+
+// MinGasPrices returns the minimum gas prices accepted by the node.
+func (c *Client) MinGasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	r, err := c.nodeClient.Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.ParseDecCoins(r.MinimumGasPrice)
+}
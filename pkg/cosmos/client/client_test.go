@@ -1,7 +1,10 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -48,6 +51,48 @@ func TestErrMatch(t *testing.T) {
 	assert.Equal(t, m[1], "10000")
 }
 
+func TestClient_simulate(t *testing.T) {
+	// response is the result of the abci_query of each call
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Params struct {
+				Path string `json:"path"`
+			} `json:"params"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, simulatePath, req.Params.Path)
+		_, err := fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"response":%s}}`, req.ID, response)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient("chain", srv.URL, DefaultTimeout, logger.Test(t))
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+
+	t.Run("gas", func(t *testing.T) {
+		value, err := json.Marshal([]byte(`{"gas_info":{"gas_wanted":"0","gas_used":"12345"},"result":{"msg_responses":[{"@type":"/unknown.Response"}]}}`))
+		require.NoError(t, err)
+		response = fmt.Sprintf(`{"value":%s}`, value)
+		res, err := c.Simulate(ctx, []byte{0x01})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12345), res.GasInfo.GasUsed)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		// Classified by code, even though the contract error mentions running out of gas and another msg
+		response = `{"code":5,"codespace":"wasm","log":"failed to simulate tx: failed to execute message; message index: 1: ` +
+			`out of gas, failed to execute message; message index: 7: execute wasm contract failed"}`
+		_, err := c.Simulate(ctx, []byte{0x01})
+		var simErr *SimulationError
+		require.ErrorAs(t, err, &simErr)
+		assert.ErrorIs(t, err, ErrContractExecution)
+		assert.NotErrorIs(t, err, ErrOutOfGas)
+		assert.Equal(t, 1, simErr.MsgIndex)
+	})
+}
+
 func TestBatchSim(t *testing.T) {
 	accounts, testdir, tendermintURL := SetupLocalCosmosNode(t, "42", "ucosm")
 
@@ -88,6 +133,7 @@ func TestBatchSim(t *testing.T) {
 		assert.Equal(t, 0, len(res.Succeeded))
 		require.Equal(t, 1, len(res.Failed))
 		assert.Equal(t, int64(1), res.Failed[0].ID)
+		assert.Error(t, res.Failed[0].Err)
 	})
 
 	t.Run("multi failure", func(t *testing.T) {
@@ -167,7 +213,7 @@ func TestCosmosClient(t *testing.T) {
 		require.NoError(t, err)
 		gasPrices, err := gpe.GasPrices()
 		require.NoError(t, err)
-		txBytes, err := tc.CreateAndSign([]sdk.Msg{fund}, an, sn, gasLimit.GasInfo.GasUsed, DefaultGasLimitMultiplier, gasPrices["ucosm"], accounts[0].PrivateKey, 0)
+		txBytes, err := tc.CreateAndSign([]sdk.Msg{fund}, an, sn, gasLimit.GasInfo.GasUsed, DefaultGasLimitMultiplier, gasPrices["ucosm"], nil, accounts[0].PrivateKey, 0)
 		require.NoError(t, err)
 		_, err = tc.Simulate(ctx, txBytes)
 		require.NoError(t, err)
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"google.golang.org/grpc/status"
+)
+
+// TxError is an ABCI error returned by a node for a tx which failed checks or execution.
+// Errors are matched by codespace and code, so errors.Is(err, ErrOutOfGas) is true for any out of gas TxError.
+type TxError struct {
+	Codespace string
+	Code      uint32
+	Log       string
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("tx failed with error code: %d, codespace: %s, log: %s", e.Code, e.Codespace, e.Log)
+}
+
+// Is returns true if target is a TxError with the same codespace and code.
+func (e *TxError) Is(target error) bool {
+	t, ok := target.(*TxError)
+	return ok && t.Codespace == e.Codespace && t.Code == e.Code
+}
+
+// registeredError is the subset of the methods of a registered cosmos-sdk error used to classify errors.
+type registeredError interface {
+	error
+	Codespace() string
+	ABCICode() uint32
+}
+
+func newTxError(err registeredError) *TxError {
+	return &TxError{Codespace: err.Codespace(), Code: err.ABCICode(), Log: err.Error()}
+}
+
+var (
+	// ErrOutOfGas is returned when a tx ran out of gas (sdk code 11).
+	ErrOutOfGas = newTxError(sdkerrors.ErrOutOfGas)
+	// ErrInsufficientFee is returned when the fee of a tx is below the node's minimum (sdk code 13).
+	ErrInsufficientFee = newTxError(sdkerrors.ErrInsufficientFee)
+	// ErrTxInMempool is returned when a tx is broadcast to a node which already has it in its mempool (sdk code 19).
+	ErrTxInMempool = newTxError(sdkerrors.ErrTxInMempoolCache)
+	// ErrMempoolFull is returned when the node's mempool has no room for a tx (sdk code 20).
+	ErrMempoolFull = newTxError(sdkerrors.ErrMempoolIsFull)
+	// ErrSequenceMismatch is returned when a tx was signed with the wrong sequence number (sdk code 32).
+	ErrSequenceMismatch = newTxError(sdkerrors.ErrWrongSequence)
+	// ErrContractExecution is returned when a wasm contract failed to execute a msg.
+	ErrContractExecution = newTxError(wasmtypes.ErrExecuteFailed)
+
+	// ErrTxNotFound is returned by Tx when the node does not know of a tx.
+	ErrTxNotFound = errors.New("tx not found")
+)
+
+// knownErrors are the errors which simulation failures are classified as.
+var knownErrors = []*TxError{ErrOutOfGas, ErrInsufficientFee, ErrTxInMempool, ErrMempoolFull, ErrSequenceMismatch, ErrContractExecution}
+
+// SimulationError is returned when simulating a tx fails.
+// Failures are classified by their ABCI codespace and code when the node returns them, and otherwise by their
+// description, e.g. for errors from the gRPC Simulate service, which drops the code.
+type SimulationError struct {
+	// MsgIndex is the index of the msg which failed, or -1 if the tx failed outside of a msg, e.g. in the ante handler.
+	MsgIndex int
+	// Code is the ABCI error of the failure, or the known error it was classified as, or nil.
+	Code *TxError
+	Err  error
+}
+
+// failedMsgIndexRe matches the index of the msg which failed, at the start of the description of the failure,
+// after any gRPC status or simulation prefixes. Msgs nested in an authz MsgExec fail with the index of the MsgExec in the tx
+// followed by the index within the MsgExec, and contract errors may contain anything, so only a leading match
+// is the tx level index.
+var failedMsgIndexRe = regexp.MustCompile(`^(?:rpc error: code = \w+ desc = |failed to simulate tx: )*failed to execute message; message index: (\d+)`)
+
+func newSimulationError(err error) *SimulationError {
+	e := &SimulationError{MsgIndex: -1, Err: err}
+	desc := err.Error()
+	if st, ok := status.FromError(err); ok {
+		desc = st.Message()
+	}
+	var txErr *TxError
+	if errors.As(err, &txErr) {
+		desc = txErr.Log
+	}
+	if m := failedMsgIndexRe.FindStringSubmatch(desc); len(m) == 2 {
+		if index, perr := strconv.ParseInt(m[1], 10, 32); perr == nil {
+			e.MsgIndex = int(index)
+		}
+	}
+	var regErr registeredError
+	switch {
+	case txErr != nil:
+		e.Code = txErr
+	case errors.As(err, &regErr):
+		e.Code = newTxError(regErr)
+	default:
+		// Last resort, since the description may contain anything, e.g. the error of a contract.
+		for _, known := range knownErrors {
+			if strings.Contains(desc, known.Log) {
+				e.Code = known
+				break
+			}
+		}
+	}
+	return e
+}
+
+func (e *SimulationError) Error() string { return e.Err.Error() }
+
+func (e *SimulationError) Unwrap() error { return e.Err }
+
+// Is returns true if the failure was classified as target.
+func (e *SimulationError) Is(target error) bool {
+	return e.Code != nil && e.Code.Is(target)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTxError(t *testing.T) {
+	err := fmt.Errorf("broadcast: %w", &TxError{Codespace: "sdk", Code: 32, Log: "account sequence mismatch, expected 9, got 5: incorrect account sequence"})
+	assert.ErrorIs(t, err, ErrSequenceMismatch)
+	assert.NotErrorIs(t, err, ErrOutOfGas)
+	assert.NotErrorIs(t, &TxError{Codespace: "wasm", Code: 32}, ErrSequenceMismatch, "different codespace")
+}
+
+func TestSimulationError(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		err   string
+		index int
+		code  error
+	}{
+		{"contract", "failed to execute message; message index: 10: Error parsing into type my_first_contract::msg::ExecuteMsg: unknown variant `blah`, expected `increment` or `reset`: execute wasm contract failed: invalid request", 10, ErrContractExecution},
+		{"authz", "failed to execute message; message index: 2: failed to execute message; message index: 0: Error parsing into type ocr2::msg::ExecuteMsg: execute wasm contract failed: invalid request", 2, ErrContractExecution},
+		{"sequence", "account sequence mismatch, expected 9, got 5: incorrect account sequence", -1, ErrSequenceMismatch},
+		{"out of gas", "out of gas in location: ReadFlat; gasWanted: 100, gasUsed: 1000: out of gas", -1, ErrOutOfGas},
+		{"unknown", "connection refused", -1, nil},
+		{"nested index only", "execute wasm contract failed: failed to execute message; message index: 3", -1, ErrContractExecution},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newSimulationError(status.Error(codes.Unknown, tt.err))
+			assert.Equal(t, tt.index, err.MsgIndex)
+			assert.Equal(t, codes.Unknown, status.Code(err), "unwraps to the grpc error")
+			if tt.code == nil {
+				assert.Nil(t, err.Code)
+				for _, known := range knownErrors {
+					assert.NotErrorIs(t, err, known)
+				}
+				return
+			}
+			assert.ErrorIs(t, err, tt.code)
+			var simErr *SimulationError
+			require.True(t, errors.As(fmt.Errorf("simulate: %w", err), &simErr))
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/fee"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -38,7 +42,21 @@ func (gpe *FixedGasPriceEstimator) GasPrice(coin string) (sdk.DecCoin, error) {
 	return price, nil
 }
 
-// BumpGasPrice calculates a new gas price by bumping the current gas price by a percentage.
+// CalculateBumpGasPrice calculates a new gas price by bumping the current gas price by a percentage.
+// See the package level CalculateBumpGasPrice for a description of the parameters.
+func (gpe *FixedGasPriceEstimator) CalculateBumpGasPrice(
+	coin string,
+	currentGasPrice,
+	originalGasPrice,
+	maxGasPrice,
+	maxBumpPrice,
+	bumpMin sdk.DecCoin,
+	bumpPercent uint16,
+) (sdk.DecCoin, error) {
+	return CalculateBumpGasPrice(gpe.lggr, coin, currentGasPrice, originalGasPrice, maxGasPrice, maxBumpPrice, bumpMin, bumpPercent)
+}
+
+// CalculateBumpGasPrice calculates a new gas price by bumping the current gas price by a percentage.
 // Parameters:
 // - currentGasPrice: The current gas price before bumping in the current round. May have already been bumped previously.
 // - originalGasPrice: The original base gas price before any bumping.
@@ -46,7 +64,10 @@ func (gpe *FixedGasPriceEstimator) GasPrice(coin string) (sdk.DecCoin, error) {
 // - maxBumpPrice: max gas price that can be bumped to
 // - bumpMin: min gas price that can be bumped by
 // - bumpPercent: percentage to bump by
-func (gpe *FixedGasPriceEstimator) CalculateBumpGasPrice(
+//
+// If the bumped price would exceed the max, the max price is returned along with an error wrapping fee.ErrBumpFeeExceedsLimit.
+func CalculateBumpGasPrice(
+	lggr logger.SugaredLogger,
 	coin string,
 	currentGasPrice,
 	originalGasPrice,
@@ -56,7 +77,7 @@ func (gpe *FixedGasPriceEstimator) CalculateBumpGasPrice(
 	bumpPercent uint16,
 ) (sdk.DecCoin, error) {
 	bumpedGasPrice, err := fee.CalculateBumpedFee(
-		gpe.lggr,
+		lggr,
 		currentGasPrice.Amount.BigInt(),
 		originalGasPrice.Amount.BigInt(),
 		maxGasPrice.Amount.BigInt(),
@@ -65,10 +86,10 @@ func (gpe *FixedGasPriceEstimator) CalculateBumpGasPrice(
 		bumpPercent,
 		FormatGasPrice,
 	)
-	if err != nil {
+	if bumpedGasPrice == nil {
 		return sdk.DecCoin{}, err
 	}
-	return sdk.NewDecCoinFromDec(coin, sdk.NewDecFromBigIntWithPrec(bumpedGasPrice, 18)), nil
+	return sdk.NewDecCoinFromDec(coin, sdk.NewDecFromBigIntWithPrec(bumpedGasPrice, sdk.Precision)), err
 }
 
 // Useful for hot reloads of configured prices
@@ -109,16 +130,105 @@ func (gpe *CachingGasPriceEstimator) GasPrices() (map[string]sdk.DecCoin, error)
 	return latestPrices, nil
 }
 
+var _ GasPricesEstimator = (*FeeMarketGasPriceEstimator)(nil)
+
+// FeeMarketGasPriceEstimator estimates gas prices from the chain's fee market module,
+// or from the node's minimum gas prices if the chain does not have one.
+// Returns an error if there is no price for denom, so that a ComposedGasPriceEstimator falls back to the next estimator.
+// Prices are cached for ttl, since they change at most once per block. A ttl of 0 disables caching.
+type FeeMarketGasPriceEstimator struct {
+	reader func() (GasPricesReader, error)
+	denom  string
+	ttl    time.Duration
+	lggr   logger.SugaredLogger
+
+	mu      sync.Mutex
+	prices  map[string]sdk.DecCoin
+	updated time.Time
+}
+
+func NewFeeMarketGasPriceEstimator(reader func() (GasPricesReader, error), denom string, ttl time.Duration, lggr logger.Logger) *FeeMarketGasPriceEstimator {
+	return &FeeMarketGasPriceEstimator{reader: reader, denom: denom, ttl: ttl, lggr: logger.Sugared(logger.Named(lggr, "FeeMarketGasPriceEstimator"))}
+}
+
+func (gpe *FeeMarketGasPriceEstimator) GasPrices() (map[string]sdk.DecCoin, error) {
+	gpe.mu.Lock()
+	defer gpe.mu.Unlock()
+	if gpe.prices != nil && time.Since(gpe.updated) < gpe.ttl {
+		return maps.Clone(gpe.prices), nil
+	}
+	prices, err := gpe.fetchGasPrices()
+	if err != nil {
+		return nil, err
+	}
+	gpe.prices, gpe.updated = prices, time.Now()
+	return maps.Clone(prices), nil
+}
+
+// fetchGasPrices reads the current gas prices from the chain.
+func (gpe *FeeMarketGasPriceEstimator) fetchGasPrices() (map[string]sdk.DecCoin, error) {
+	reader, err := gpe.reader()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	prices, err := reader.FeeMarketGasPrices(ctx)
+	if err != nil {
+		gpe.lggr.Debugw("unable to get fee market gas prices, using node minimum gas prices", "err", err)
+		prices, err = reader.MinGasPrices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get minimum gas prices: %w", err)
+		}
+	}
+	gasPrices := make(map[string]sdk.DecCoin, len(prices))
+	for _, p := range prices {
+		if p.IsPositive() {
+			gasPrices[p.Denom] = p
+		}
+	}
+	if _, ok := gasPrices[gpe.denom]; !ok {
+		return nil, fmt.Errorf("no gas price for %s from chain, got %s", gpe.denom, prices)
+	}
+	return gasPrices, nil
+}
+
+var _ GasPricesEstimator = (*ComposedGasPriceEstimator)(nil)
+
+// ComposedGasPriceEstimator returns the gas prices from the first of its estimators which succeeds,
+// capped at maxGasPrices. It keeps the last prices returned, and reports unhealthy while every estimator is failing.
 type ComposedGasPriceEstimator struct {
-	estimators []GasPricesEstimator
-	lggr       logger.Logger
+	estimators   []GasPricesEstimator
+	maxGasPrices sdk.DecCoins
+	lggr         logger.SugaredLogger
+
+	mu          sync.RWMutex
+	lastPrices  map[string]sdk.DecCoin
+	lastSuccess time.Time
+	lastErr     error
 }
 
+// NewComposedGasPriceEstimator returns a ComposedGasPriceEstimator. Prices are capped at the amount
+// of the same denom in maxGasPrices, if any.
+func NewComposedGasPriceEstimator(estimators []GasPricesEstimator, maxGasPrices sdk.DecCoins, lggr logger.Logger) *ComposedGasPriceEstimator {
+	return &ComposedGasPriceEstimator{
+		estimators:   estimators,
+		maxGasPrices: maxGasPrices,
+		lggr:         logger.Sugared(logger.Named(lggr, "ComposedGasPriceEstimator")),
+	}
+}
+
+// NewMustGasPriceEstimator returns a ComposedGasPriceEstimator without any max gas prices.
+//
+// Deprecated: use NewComposedGasPriceEstimator
 func NewMustGasPriceEstimator(estimators []GasPricesEstimator, lggr logger.Logger) *ComposedGasPriceEstimator {
-	return &ComposedGasPriceEstimator{estimators: estimators, lggr: logger.Named(lggr, "ComposedGasPriceEstimator")}
+	return NewComposedGasPriceEstimator(estimators, nil, lggr)
 }
 
-func (gpe *ComposedGasPriceEstimator) GasPrices() map[string]sdk.DecCoin {
+func (gpe *ComposedGasPriceEstimator) Name() string { return gpe.lggr.Name() }
+
+// GasPrices returns the prices from the first estimator which succeeds, or an error if they all fail.
+func (gpe *ComposedGasPriceEstimator) GasPrices() (map[string]sdk.DecCoin, error) {
 	// Try each estimator in order
 	var finalError error
 	for _, estimator := range gpe.estimators {
@@ -128,9 +238,49 @@ func (gpe *ComposedGasPriceEstimator) GasPrices() map[string]sdk.DecCoin {
 			gpe.lggr.Warnf("error using estimator, trying next one, err %v", err)
 			continue
 		}
-		return latestPrices
+		prices := gpe.capPrices(latestPrices)
+		gpe.mu.Lock()
+		gpe.lastPrices, gpe.lastSuccess, gpe.lastErr = prices, time.Now(), nil
+		gpe.mu.Unlock()
+		return prices, nil
+	}
+	err := fmt.Errorf("no estimator succeeded: %w", finalError)
+	gpe.mu.Lock()
+	gpe.lastErr = err
+	gpe.mu.Unlock()
+	return nil, err
+}
+
+// capPrices returns a copy of prices, with any above maxGasPrices reduced to the max.
+func (gpe *ComposedGasPriceEstimator) capPrices(prices map[string]sdk.DecCoin) map[string]sdk.DecCoin {
+	capped := make(map[string]sdk.DecCoin, len(prices))
+	for denom, price := range prices {
+		if maxPrice := gpe.maxGasPrices.AmountOf(denom); maxPrice.IsPositive() && price.Amount.GT(maxPrice) {
+			gpe.lggr.Warnw("gas price exceeds max, using max instead", "price", price, "max", maxPrice)
+			price = sdk.NewDecCoinFromDec(denom, maxPrice)
+		}
+		capped[denom] = price
+	}
+	return capped
+}
+
+// LastPrices returns the last prices returned by GasPrices, and when they were returned.
+func (gpe *ComposedGasPriceEstimator) LastPrices() (map[string]sdk.DecCoin, time.Time) {
+	gpe.mu.RLock()
+	defer gpe.mu.RUnlock()
+	return gpe.lastPrices, gpe.lastSuccess
+}
+
+// HealthReport reports an error if every estimator failed on the last call to GasPrices,
+// along with the last prices which were returned.
+func (gpe *ComposedGasPriceEstimator) HealthReport() map[string]error {
+	gpe.mu.RLock()
+	defer gpe.mu.RUnlock()
+	err := gpe.lastErr
+	if err != nil && gpe.lastPrices != nil {
+		err = fmt.Errorf("last prices were %v at %s: %w", gpe.lastPrices, gpe.lastSuccess, err)
 	}
-	panic(fmt.Sprintf("no estimator succeeded errs %v", finalError))
+	return map[string]error{gpe.Name(): err}
 }
 
 func FormatGasPrice(gasPrice *big.Int) string {
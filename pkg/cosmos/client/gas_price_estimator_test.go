@@ -1,12 +1,15 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -90,19 +93,163 @@ func TestGasPriceEstimators(t *testing.T) {
 		gpeFixed := NewFixedGasPriceEstimator(map[string]sdk.DecCoin{
 			"ucosm": sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("10")),
 		}, sugaredLggr)
-		gpe := NewMustGasPriceEstimator([]GasPricesEstimator{cachingGpe, gpeFixed}, lggr)
+		gpe := NewComposedGasPriceEstimator([]GasPricesEstimator{cachingGpe, gpeFixed}, nil, lggr)
 		t.Cleanup(assertLogsLen(t, 1))
-		fixedPrices := gpe.GasPrices()
+		fixedPrices, err := gpe.GasPrices()
+		require.NoError(t, err)
 		ucosm, ok := fixedPrices["ucosm"]
 		assert.True(t, ok)
 		assert.Equal(t, "10.000000000000000000", ucosm.Amount.String())
 		// If the url starts working, it should use that.
 		responses = append(responses, sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("9")))
-		gpePrices := gpe.GasPrices()
+		gpePrices, err := gpe.GasPrices()
+		require.NoError(t, err)
 		ucosm, ok = gpePrices["ucosm"]
 		assert.True(t, ok)
 		assert.NotEqual(t, "10.000000000000000000", ucosm.Amount.String())
 	})
+
+	t.Run("composed all failing", func(t *testing.T) {
+		fail := true
+		closureGpe := NewClosureGasPriceEstimator(func() (map[string]sdk.DecCoin, error) {
+			if fail {
+				return nil, errors.New("no prices")
+			}
+			return map[string]sdk.DecCoin{
+				"ucosm": sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("10")),
+			}, nil
+		})
+		gpe := NewComposedGasPriceEstimator([]GasPricesEstimator{closureGpe}, nil, lggr)
+		t.Cleanup(assertLogsLen(t, 2))
+		_, err := gpe.GasPrices()
+		require.ErrorContains(t, err, "no prices")
+		assert.ErrorContains(t, gpe.HealthReport()[gpe.Name()], "no prices")
+		prices, _ := gpe.LastPrices()
+		assert.Nil(t, prices)
+
+		fail = false
+		_, err = gpe.GasPrices()
+		require.NoError(t, err)
+		assert.NoError(t, gpe.HealthReport()[gpe.Name()])
+		prices, at := gpe.LastPrices()
+		assert.Equal(t, "10.000000000000000000", prices["ucosm"].Amount.String())
+		assert.False(t, at.IsZero())
+
+		// The last prices are reported along with the error
+		fail = true
+		_, err = gpe.GasPrices()
+		require.Error(t, err)
+		assert.ErrorContains(t, gpe.HealthReport()[gpe.Name()], "10.000000000000000000ucosm")
+	})
+
+	t.Run("composed max gas price", func(t *testing.T) {
+		gpeFixed := NewFixedGasPriceEstimator(map[string]sdk.DecCoin{
+			"ucosm": sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("10")),
+			"uatom": sdk.NewDecCoinFromDec("uatom", sdk.MustNewDecFromStr("10")),
+		}, sugaredLggr)
+		gpe := NewComposedGasPriceEstimator([]GasPricesEstimator{gpeFixed}, sdk.NewDecCoins(sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("1.5"))), lggr)
+		t.Cleanup(assertLogsLen(t, 1))
+		prices, err := gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "1.500000000000000000", prices["ucosm"].Amount.String())
+		assert.Equal(t, "10.000000000000000000", prices["uatom"].Amount.String(), "no max for uatom")
+		p, err := gpeFixed.GasPrice("ucosm")
+		require.NoError(t, err)
+		assert.Equal(t, "10.000000000000000000", p.Amount.String(), "estimator prices are not modified")
+	})
+}
+
+type fakeGasPricesReader struct {
+	feeMarket, min sdk.DecCoins
+	feeMarketErr   error
+}
+
+func (f *fakeGasPricesReader) FeeMarketGasPrices(context.Context) (sdk.DecCoins, error) {
+	return f.feeMarket, f.feeMarketErr
+}
+
+func (f *fakeGasPricesReader) MinGasPrices(context.Context) (sdk.DecCoins, error) {
+	return f.min, nil
+}
+
+func TestFeeMarketGasPriceEstimator(t *testing.T) {
+	lggr := logger.Test(t)
+	reader := &fakeGasPricesReader{}
+	gpe := NewFeeMarketGasPriceEstimator(func() (GasPricesReader, error) { return reader, nil }, "ucosm", 0, lggr)
+
+	t.Run("fee market", func(t *testing.T) {
+		reader.feeMarket = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("0.025")))
+		reader.min = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("0.01")))
+		p, err := gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "0.025000000000000000", p["ucosm"].Amount.String())
+	})
+
+	t.Run("min gas prices", func(t *testing.T) {
+		reader.feeMarketErr = errors.New("unknown query path")
+		t.Cleanup(func() { reader.feeMarketErr = nil })
+		p, err := gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "0.010000000000000000", p["ucosm"].Amount.String())
+	})
+
+	t.Run("missing denom", func(t *testing.T) {
+		reader.feeMarket = sdk.NewDecCoins(sdk.NewDecCoinFromDec("uatom", sdk.MustNewDecFromStr("0.025")))
+		_, err := gpe.GasPrices()
+		require.Error(t, err)
+
+		// Falls back to the next estimator
+		gpeFixed := NewFixedGasPriceEstimator(map[string]sdk.DecCoin{
+			"ucosm": sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("10")),
+		}, logger.Sugared(lggr))
+		composed := NewComposedGasPriceEstimator([]GasPricesEstimator{gpe, gpeFixed}, nil, lggr)
+		prices, err := composed.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "10.000000000000000000", prices["ucosm"].Amount.String())
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		reader := &fakeGasPricesReader{feeMarket: sdk.NewDecCoins(sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("0.025")))}
+		gpe := NewFeeMarketGasPriceEstimator(func() (GasPricesReader, error) { return reader, nil }, "ucosm", time.Hour, lggr)
+		p, err := gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "0.025000000000000000", p["ucosm"].Amount.String())
+		delete(p, "ucosm")
+
+		reader.feeMarket = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("0.05")))
+		p, err = gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "0.025000000000000000", p["ucosm"].Amount.String(), "cached until the ttl")
+
+		gpe.updated = time.Now().Add(-time.Hour)
+		p, err = gpe.GasPrices()
+		require.NoError(t, err)
+		assert.Equal(t, "0.050000000000000000", p["ucosm"].Amount.String())
+	})
+}
+
+func TestDecodeFeeMarketGasPrices(t *testing.T) {
+	exp := sdk.NewDecCoins(
+		sdk.NewDecCoinFromDec("uatom", sdk.MustNewDecFromStr("0.5")),
+		sdk.NewDecCoinFromDec("ucosm", sdk.MustNewDecFromStr("0.025")),
+	)
+	var raw []byte
+	for _, c := range exp {
+		b, err := c.Marshal()
+		require.NoError(t, err)
+		raw = protowire.AppendTag(raw, 1, protowire.BytesType)
+		raw = protowire.AppendBytes(raw, b)
+	}
+	// Unknown fields are skipped
+	raw = protowire.AppendTag(raw, 2, protowire.VarintType)
+	raw = protowire.AppendVarint(raw, 7)
+
+	prices, err := decodeFeeMarketGasPrices(raw)
+	require.NoError(t, err)
+	assert.Equal(t, exp, prices)
+
+	_, err = decodeFeeMarketGasPrices([]byte{0x0a, 0x05})
+	require.Error(t, err)
 }
 
 func TestFixedPriceGasEstimator(t *testing.T) {
@@ -3,14 +3,17 @@
 package mocks
 
 import (
-	context "context"
-
+	cometbfttypes "github.com/cometbft/cometbft/types"
 	client "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
 
+	context "context"
+
 	cosmos_sdkclient "github.com/cosmos/cosmos-sdk/client"
 
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 
+	feegrant "github.com/cosmos/cosmos-sdk/x/feegrant"
+
 	mock "github.com/stretchr/testify/mock"
 
 	query "github.com/cosmos/cosmos-sdk/types/query"
@@ -182,6 +185,36 @@ func (_m *ReaderWriter) Broadcast(ctx context.Context, txBytes []byte, mode tx.B
 	return r0, r1
 }
 
+// ConsensusParams provides a mock function with given fields: ctx
+func (_m *ReaderWriter) ConsensusParams(ctx context.Context) (*cometbfttypes.ConsensusParams, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsensusParams")
+	}
+
+	var r0 *cometbfttypes.ConsensusParams
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cometbfttypes.ConsensusParams, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cometbfttypes.ConsensusParams); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cometbfttypes.ConsensusParams)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Context provides a mock function with given fields:
 func (_m *ReaderWriter) Context() *cosmos_sdkclient.Context {
 	ret := _m.Called()
@@ -232,9 +265,9 @@ func (_m *ReaderWriter) ContractState(ctx context.Context, contractAddress types
 	return r0, r1
 }
 
-// CreateAndSign provides a mock function with given fields: msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, signer, timeoutHeight
-func (_m *ReaderWriter) CreateAndSign(msgs []types.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice types.DecCoin, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error) {
-	ret := _m.Called(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, signer, timeoutHeight)
+// CreateAndSign provides a mock function with given fields: msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, feeGranter, signer, timeoutHeight
+func (_m *ReaderWriter) CreateAndSign(msgs []types.Msg, account uint64, sequence uint64, gasLimit uint64, gasLimitMultiplier float64, gasPrice types.DecCoin, feeGranter types.AccAddress, signer cryptotypes.PrivKey, timeoutHeight uint64) ([]byte, error) {
+	ret := _m.Called(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, feeGranter, signer, timeoutHeight)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAndSign")
@@ -242,19 +275,79 @@ func (_m *ReaderWriter) CreateAndSign(msgs []types.Msg, account uint64, sequence
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, cryptotypes.PrivKey, uint64) ([]byte, error)); ok {
-		return rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, signer, timeoutHeight)
+	if rf, ok := ret.Get(0).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, types.AccAddress, cryptotypes.PrivKey, uint64) ([]byte, error)); ok {
+		return rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, feeGranter, signer, timeoutHeight)
 	}
-	if rf, ok := ret.Get(0).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, cryptotypes.PrivKey, uint64) []byte); ok {
-		r0 = rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, signer, timeoutHeight)
+	if rf, ok := ret.Get(0).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, types.AccAddress, cryptotypes.PrivKey, uint64) []byte); ok {
+		r0 = rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, feeGranter, signer, timeoutHeight)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, cryptotypes.PrivKey, uint64) error); ok {
-		r1 = rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, signer, timeoutHeight)
+	if rf, ok := ret.Get(1).(func([]types.Msg, uint64, uint64, uint64, float64, types.DecCoin, types.AccAddress, cryptotypes.PrivKey, uint64) error); ok {
+		r1 = rf(msgs, account, sequence, gasLimit, gasLimitMultiplier, gasPrice, feeGranter, signer, timeoutHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FeeAllowance provides a mock function with given fields: ctx, granter, grantee
+func (_m *ReaderWriter) FeeAllowance(ctx context.Context, granter types.AccAddress, grantee types.AccAddress) (feegrant.FeeAllowanceI, error) {
+	ret := _m.Called(ctx, granter, grantee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FeeAllowance")
+	}
+
+	var r0 feegrant.FeeAllowanceI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.AccAddress, types.AccAddress) (feegrant.FeeAllowanceI, error)); ok {
+		return rf(ctx, granter, grantee)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.AccAddress, types.AccAddress) feegrant.FeeAllowanceI); ok {
+		r0 = rf(ctx, granter, grantee)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(feegrant.FeeAllowanceI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.AccAddress, types.AccAddress) error); ok {
+		r1 = rf(ctx, granter, grantee)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FeeMarketGasPrices provides a mock function with given fields: ctx
+func (_m *ReaderWriter) FeeMarketGasPrices(ctx context.Context) (types.DecCoins, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FeeMarketGasPrices")
+	}
+
+	var r0 types.DecCoins
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (types.DecCoins, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) types.DecCoins); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.DecCoins)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -292,6 +385,36 @@ func (_m *ReaderWriter) LatestBlock(_a0 context.Context) (*tmservice.GetLatestBl
 	return r0, r1
 }
 
+// MinGasPrices provides a mock function with given fields: ctx
+func (_m *ReaderWriter) MinGasPrices(ctx context.Context) (types.DecCoins, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MinGasPrices")
+	}
+
+	var r0 types.DecCoins
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (types.DecCoins, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) types.DecCoins); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.DecCoins)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignAndBroadcast provides a mock function with given fields: ctx, msgs, accountNum, sequence, gasPrice, signer, mode
 func (_m *ReaderWriter) SignAndBroadcast(ctx context.Context, msgs []types.Msg, accountNum uint64, sequence uint64, gasPrice types.DecCoin, signer cryptotypes.PrivKey, mode tx.BroadcastMode) (*tx.BroadcastTxResponse, error) {
 	ret := _m.Called(ctx, msgs, accountNum, sequence, gasPrice, signer, mode)
@@ -382,6 +505,36 @@ func (_m *ReaderWriter) SimulateUnsigned(ctx context.Context, msgs []types.Msg,
 	return r0, r1
 }
 
+// SubscribeTx provides a mock function with given fields: ctx, hash
+func (_m *ReaderWriter) SubscribeTx(ctx context.Context, hash string) (<-chan struct{}, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeTx")
+	}
+
+	var r0 <-chan struct{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan struct{}, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan struct{}); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Tx provides a mock function with given fields: ctx, hash
 func (_m *ReaderWriter) Tx(ctx context.Context, hash string) (*tx.GetTxResponse, error) {
 	ret := _m.Called(ctx, hash)
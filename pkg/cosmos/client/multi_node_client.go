@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// Broadcaster broadcasts signed txs to a node.
+type Broadcaster interface {
+	Broadcast(ctx context.Context, txBytes []byte, mode txtypes.BroadcastMode) (*txtypes.BroadcastTxResponse, error)
+}
+
+var _ ReaderWriter = (*MultiNodeClient)(nil)
+
+// MultiNodeClient is a ReaderWriter which broadcasts txs to several nodes concurrently,
+// so that a tx is not lost when one node can not take it. Everything else goes through a single node.
+type MultiNodeClient struct {
+	ReaderWriter
+	broadcasters map[string]Broadcaster // by node name
+	lggr         logger.SugaredLogger
+}
+
+// NewMultiNodeClient returns a MultiNodeClient which uses rw, except to broadcast txs to each of broadcasters.
+func NewMultiNodeClient(rw ReaderWriter, broadcasters map[string]Broadcaster, lggr logger.Logger) *MultiNodeClient {
+	return &MultiNodeClient{
+		ReaderWriter: rw,
+		broadcasters: broadcasters,
+		lggr:         logger.Sugared(logger.Named(lggr, "MultiNodeClient")),
+	}
+}
+
+// Broadcasters returns the number of nodes txs are broadcast to.
+func (c *MultiNodeClient) Broadcasters() int { return len(c.broadcasters) }
+
+// Broadcast broadcasts txBytes to all nodes, and returns the first successful response.
+// ErrTxInMempool counts as success, since the node already has the tx. If no node accepts the tx,
+// the errors from all of them are returned.
+func (c *MultiNodeClient) Broadcast(ctx context.Context, txBytes []byte, mode txtypes.BroadcastMode) (*txtypes.BroadcastTxResponse, error) {
+	type result struct {
+		name string
+		res  *txtypes.BroadcastTxResponse
+		err  error
+	}
+	// Buffered so that the remaining broadcasts can finish once a response has been returned
+	results := make(chan result, len(c.broadcasters))
+	for name, b := range c.broadcasters {
+		go func(name string, b Broadcaster) {
+			res, err := b.Broadcast(ctx, txBytes, mode)
+			results <- result{name: name, res: res, err: err}
+		}(name, b)
+	}
+	var errs error
+	for range c.broadcasters {
+		r := <-results
+		if r.err == nil || (errors.Is(r.err, ErrTxInMempool) && r.res != nil && r.res.TxResponse != nil) {
+			c.lggr.Debugw("broadcast tx", "node", r.name, "err", r.err)
+			return r.res, nil
+		}
+		c.lggr.Debugw("unable to broadcast tx", "node", r.name, "err", r.err)
+		errs = errors.Join(errs, fmt.Errorf("node %s: %w", r.name, r.err))
+	}
+	if errs == nil {
+		return nil, errors.New("no nodes to broadcast to")
+	}
+	return nil, errs
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+type broadcasterFunc func() (*txtypes.BroadcastTxResponse, error)
+
+func (f broadcasterFunc) Broadcast(context.Context, []byte, txtypes.BroadcastMode) (*txtypes.BroadcastTxResponse, error) {
+	return f()
+}
+
+func TestMultiNodeClient_Broadcast(t *testing.T) {
+	ctx := tests.Context(t)
+	lggr := logger.Test(t)
+	ok := &txtypes.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "ABCD"}}
+	failed := func(err error) Broadcaster {
+		return broadcasterFunc(func() (*txtypes.BroadcastTxResponse, error) { return nil, err })
+	}
+	succeeded := broadcasterFunc(func() (*txtypes.BroadcastTxResponse, error) { return ok, nil })
+
+	t.Run("first success", func(t *testing.T) {
+		slow := broadcasterFunc(func() (*txtypes.BroadcastTxResponse, error) {
+			<-ctx.Done() // never responds in time
+			return nil, ctx.Err()
+		})
+		c := NewMultiNodeClient(nil, map[string]Broadcaster{"a": failed(ErrMempoolFull), "b": succeeded, "c": slow}, lggr)
+		res, err := c.Broadcast(ctx, []byte{0x01}, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+		require.NoError(t, err)
+		assert.Equal(t, ok, res)
+	})
+
+	t.Run("already in mempool", func(t *testing.T) {
+		inMempool := &txtypes.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "ABCD", Code: ErrTxInMempool.Code}}
+		dupe := broadcasterFunc(func() (*txtypes.BroadcastTxResponse, error) {
+			return inMempool, &TxError{Codespace: ErrTxInMempool.Codespace, Code: ErrTxInMempool.Code}
+		})
+		c := NewMultiNodeClient(nil, map[string]Broadcaster{"a": failed(ErrMempoolFull), "b": dupe}, lggr)
+		res, err := c.Broadcast(ctx, []byte{0x01}, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+		require.NoError(t, err)
+		assert.Equal(t, inMempool, res)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		c := NewMultiNodeClient(nil, map[string]Broadcaster{"a": failed(ErrMempoolFull), "b": failed(ErrInsufficientFee)}, lggr)
+		_, err := c.Broadcast(ctx, []byte{0x01}, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+		assert.ErrorIs(t, err, ErrMempoolFull)
+		assert.ErrorIs(t, err, ErrInsufficientFee)
+		assert.ErrorContains(t, err, "node a")
+	})
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	tmtypes "github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+)
+
+// BlockReader provides methods for reading blocks from a cosmos chain.
+type BlockReader interface {
+	LatestBlock(context.Context) (*tmtypes.GetLatestBlockResponse, error)
+	BlockByHeight(ctx context.Context, height int64) (*tmtypes.GetBlockByHeightResponse, error)
+}
+
+var (
+	_ GasPricesEstimator = (*PercentileGasPriceEstimator)(nil)
+	_ services.Service   = (*PercentileGasPriceEstimator)(nil)
+)
+
+// PercentileGasPriceEstimator estimates gas prices from the fees paid by txs in recent blocks.
+// The prices paid per unit of gas in the last blocks are sampled in the background every pollPeriod,
+// and GasPrices returns the given percentile of them for each denom.
+// Returns an error if there is no price for denom, so that a ComposedGasPriceEstimator falls back to the next estimator.
+type PercentileGasPriceEstimator struct {
+	services.StateMachine
+	reader     func() (BlockReader, error)
+	denom      string
+	blocks     int64
+	percentile uint16
+	pollPeriod time.Duration
+	lggr       logger.SugaredLogger
+
+	mu      sync.RWMutex
+	samples map[int64]sdk.DecCoins // gas prices paid in each sampled block, by height
+	prices  map[string]sdk.DecCoin
+
+	stop services.StopChan
+	wg   sync.WaitGroup
+}
+
+func NewPercentileGasPriceEstimator(reader func() (BlockReader, error), denom string, blocks int64, percentile uint16, pollPeriod time.Duration, lggr logger.Logger) *PercentileGasPriceEstimator {
+	return &PercentileGasPriceEstimator{
+		reader:     reader,
+		denom:      denom,
+		blocks:     blocks,
+		percentile: percentile,
+		pollPeriod: pollPeriod,
+		lggr:       logger.Sugared(logger.Named(lggr, "PercentileGasPriceEstimator")),
+		samples:    make(map[int64]sdk.DecCoins),
+		stop:       make(services.StopChan),
+	}
+}
+
+func (gpe *PercentileGasPriceEstimator) Name() string { return gpe.lggr.Name() }
+
+func (gpe *PercentileGasPriceEstimator) Start(context.Context) error {
+	return gpe.StartOnce("PercentileGasPriceEstimator", func() error {
+		gpe.wg.Add(1)
+		go gpe.run()
+		return nil
+	})
+}
+
+func (gpe *PercentileGasPriceEstimator) Close() error {
+	return gpe.StopOnce("PercentileGasPriceEstimator", func() error {
+		close(gpe.stop)
+		gpe.wg.Wait()
+		return nil
+	})
+}
+
+func (gpe *PercentileGasPriceEstimator) HealthReport() map[string]error {
+	return map[string]error{gpe.Name(): gpe.Healthy()}
+}
+
+func (gpe *PercentileGasPriceEstimator) run() {
+	defer gpe.wg.Done()
+	ctx, cancel := gpe.stop.NewCtx()
+	defer cancel()
+	tick := time.After(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			if err := gpe.refresh(ctx); err != nil {
+				gpe.lggr.Warnw("unable to refresh gas prices", "err", err)
+			}
+			tick = time.After(utils.WithJitter(gpe.pollPeriod))
+		}
+	}
+}
+
+// refresh samples any blocks which are new since the last refresh, and recalculates the prices.
+func (gpe *PercentileGasPriceEstimator) refresh(ctx context.Context) error {
+	reader, err := gpe.reader()
+	if err != nil {
+		return err
+	}
+	latest, err := reader.LatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get latest block: %w", err)
+	}
+	if latest.SdkBlock == nil {
+		return errors.New("latest block is missing")
+	}
+	height := latest.SdkBlock.Header.Height
+	oldest := max(height-gpe.blocks+1, 1)
+
+	gpe.mu.RLock()
+	var toSample []int64
+	for h := oldest; h <= height; h++ {
+		if _, ok := gpe.samples[h]; !ok {
+			toSample = append(toSample, h)
+		}
+	}
+	gpe.mu.RUnlock()
+
+	sampled := make(map[int64]sdk.DecCoins, len(toSample))
+	for _, h := range toSample {
+		b, err := reader.BlockByHeight(ctx, h)
+		if err != nil {
+			// Try again on the next refresh
+			gpe.lggr.Debugw("unable to get block", "height", h, "err", err)
+			continue
+		}
+		sampled[h] = blockGasPrices(b, gpe.lggr)
+	}
+
+	gpe.mu.Lock()
+	defer gpe.mu.Unlock()
+	for h, p := range sampled {
+		gpe.samples[h] = p
+	}
+	for h := range gpe.samples {
+		if h < oldest {
+			delete(gpe.samples, h)
+		}
+	}
+	byDenom := make(map[string][]sdk.Dec)
+	for _, prices := range gpe.samples {
+		for _, p := range prices {
+			byDenom[p.Denom] = append(byDenom[p.Denom], p.Amount)
+		}
+	}
+	gpe.prices = make(map[string]sdk.DecCoin, len(byDenom))
+	for denom, amounts := range byDenom {
+		gpe.prices[denom] = sdk.NewDecCoinFromDec(denom, percentile(amounts, gpe.percentile))
+	}
+	gpe.lggr.Debugw("refreshed gas prices", "height", height, "blocks", len(gpe.samples), "prices", gpe.prices)
+	return nil
+}
+
+func (gpe *PercentileGasPriceEstimator) GasPrices() (map[string]sdk.DecCoin, error) {
+	gpe.mu.RLock()
+	defer gpe.mu.RUnlock()
+	if _, ok := gpe.prices[gpe.denom]; !ok {
+		return nil, fmt.Errorf("no gas price for %s in the last %d blocks", gpe.denom, gpe.blocks)
+	}
+	return maps.Clone(gpe.prices), nil
+}
+
+// blockGasPrices returns the price paid per unit of gas by each tx in b, in each denom of its fee.
+// Only the fee and gas limit are decoded, so txs with msgs of unknown types are included too.
+func blockGasPrices(b *tmtypes.GetBlockByHeightResponse, lggr logger.SugaredLogger) sdk.DecCoins {
+	var txs [][]byte
+	if b.SdkBlock != nil {
+		txs = b.SdkBlock.Data.Txs
+	} else if b.Block != nil {
+		txs = b.Block.Data.Txs
+	}
+	var prices sdk.DecCoins
+	for _, raw := range txs {
+		var tx txtypes.TxRaw
+		if err := tx.Unmarshal(raw); err != nil {
+			lggr.Debugw("unable to decode tx", "err", err)
+			continue
+		}
+		var authInfo txtypes.AuthInfo
+		if err := authInfo.Unmarshal(tx.AuthInfoBytes); err != nil {
+			lggr.Debugw("unable to decode tx auth info", "err", err)
+			continue
+		}
+		if authInfo.Fee == nil || authInfo.Fee.GasLimit == 0 {
+			continue
+		}
+		gasLimit := sdk.NewDecFromInt(sdk.NewIntFromUint64(authInfo.Fee.GasLimit))
+		for _, c := range authInfo.Fee.Amount {
+			if !c.IsPositive() {
+				continue
+			}
+			// Not sorted or de-duplicated, since each tx is a separate sample
+			prices = append(prices, sdk.NewDecCoinFromDec(c.Denom, sdk.NewDecFromInt(c.Amount).Quo(gasLimit)))
+		}
+	}
+	return prices
+}
+
+// percentile returns the p'th percentile of amounts, by the nearest rank method.
+func percentile(amounts []sdk.Dec, p uint16) sdk.Dec {
+	sorted := make([]sdk.Dec, len(amounts))
+	copy(sorted, amounts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LT(sorted[j]) })
+	rank := (int(p)*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
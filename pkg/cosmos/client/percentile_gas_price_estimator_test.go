@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tmtypes "github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+type fakeBlockReader struct {
+	height int64
+	blocks map[int64][][]byte
+	calls  map[int64]int
+}
+
+func (f *fakeBlockReader) LatestBlock(context.Context) (*tmtypes.GetLatestBlockResponse, error) {
+	return &tmtypes.GetLatestBlockResponse{SdkBlock: &tmtypes.Block{Header: tmtypes.Header{Height: f.height}}}, nil
+}
+
+func (f *fakeBlockReader) BlockByHeight(_ context.Context, height int64) (*tmtypes.GetBlockByHeightResponse, error) {
+	f.calls[height]++
+	txs, ok := f.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+	b := &tmtypes.Block{}
+	b.Data.Txs = txs
+	return &tmtypes.GetBlockByHeightResponse{SdkBlock: b}, nil
+}
+
+func encodeTxWithFee(t *testing.T, gasLimit uint64, fee sdk.Coins) []byte {
+	authInfo := txtypes.AuthInfo{Fee: &txtypes.Fee{Amount: fee, GasLimit: gasLimit}}
+	authInfoBytes, err := authInfo.Marshal()
+	require.NoError(t, err)
+	raw := txtypes.TxRaw{BodyBytes: []byte{}, AuthInfoBytes: authInfoBytes}
+	b, err := raw.Marshal()
+	require.NoError(t, err)
+	return b
+}
+
+func TestPercentileGasPriceEstimator(t *testing.T) {
+	ctx := tests.Context(t)
+	reader := &fakeBlockReader{height: 3, blocks: map[int64][][]byte{}, calls: map[int64]int{}}
+	ucosm := func(amt int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ucosm", amt)) }
+	reader.blocks[1] = [][]byte{encodeTxWithFee(t, 100, ucosm(100))} // 1
+	reader.blocks[2] = [][]byte{
+		encodeTxWithFee(t, 100, ucosm(200)), // 2
+		encodeTxWithFee(t, 100, ucosm(300)), // 3
+		encodeTxWithFee(t, 0, ucosm(300)),   // no gas limit, ignored
+		[]byte("not a tx"),                  // ignored
+	}
+	reader.blocks[3] = [][]byte{
+		encodeTxWithFee(t, 100, sdk.NewCoins(sdk.NewInt64Coin("ucosm", 400), sdk.NewInt64Coin("uatom", 50))), // 4, 0.5
+	}
+	gpe := NewPercentileGasPriceEstimator(func() (BlockReader, error) { return reader, nil }, "ucosm", 3, 50, 0, logger.Test(t))
+
+	_, err := gpe.GasPrices()
+	require.Error(t, err, "nothing sampled yet")
+
+	require.NoError(t, gpe.refresh(ctx))
+	prices, err := gpe.GasPrices()
+	require.NoError(t, err)
+	assert.Equal(t, "2.000000000000000000", prices["ucosm"].Amount.String())
+	assert.Equal(t, "0.500000000000000000", prices["uatom"].Amount.String())
+	delete(prices, "ucosm")
+	_, err = gpe.GasPrices()
+	require.NoError(t, err, "callers get a copy")
+
+	// Only new blocks are sampled, and old ones are dropped
+	reader.height = 4
+	reader.blocks[4] = [][]byte{encodeTxWithFee(t, 100, ucosm(500))} // 5
+	require.NoError(t, gpe.refresh(ctx))
+	prices, err = gpe.GasPrices()
+	require.NoError(t, err)
+	assert.Equal(t, "3.000000000000000000", prices["ucosm"].Amount.String())
+	assert.Equal(t, map[int64]int{1: 1, 2: 1, 3: 1, 4: 1}, reader.calls)
+
+	// Missing blocks are retried
+	reader.height = 6
+	require.NoError(t, gpe.refresh(ctx))
+	reader.blocks[5] = [][]byte{}
+	reader.blocks[6] = [][]byte{}
+	require.NoError(t, gpe.refresh(ctx))
+	assert.Equal(t, 2, reader.calls[5])
+	prices, err = gpe.GasPrices()
+	require.NoError(t, err)
+	assert.Equal(t, "5.000000000000000000", prices["ucosm"].Amount.String())
+	_, ok := prices["uatom"]
+	assert.False(t, ok)
+}
+
+func TestPercentile(t *testing.T) {
+	amounts := []sdk.Dec{sdk.NewDec(5), sdk.NewDec(1), sdk.NewDec(4), sdk.NewDec(2), sdk.NewDec(3)}
+	for p, exp := range map[uint16]int64{0: 1, 1: 1, 20: 1, 21: 2, 50: 3, 60: 3, 61: 4, 100: 5} {
+		assert.Equal(t, sdk.NewDec(exp), percentile(amounts, p), "p%d", p)
+	}
+	assert.Equal(t, sdk.NewDec(5), amounts[0], "input is not modified")
+}
@@ -19,14 +19,49 @@ import (
 
 // Global defaults.
 var defaultConfigSet = configSet{
-	BlockRate: 6 * time.Second,
+	// The balances of the keys are polled every BalancePollPeriod, and reported unhealthy once they can pay for
+	// fewer than BalanceMinTransmissions txs of BalanceTransmissionGas at the current gas price.
+	// A BalanceMinTransmissions of 0 disables the check. Keys are not checked if a FeeGranter pays their fees.
+	BalanceMinTransmissions: 10,
+	BalancePollPeriod:       time.Minute,
+	BalanceTransmissionGas:  500_000,
+	BlockRate:               6 * time.Second,
 	// ~6s per block, so ~3m until we give up on the tx getting confirmed
 	// Anecdotally it appears anything more than 4 blocks would be an extremely long wait,
 	// In practice during the UST depegging and subsequent extreme congestion, we saw
 	// ~16 block FIFO lineups.
 	BlocksUntilTxTimeout: 30,
-	ConfirmPollPeriod:    time.Second,
-	FallbackGasPrice:     sdk.MustNewDecFromStr("0.015"),
+	// Txs are broadcast to one node, plus any SendOnly nodes. BroadcastToAllNodes sends them to every
+	// node instead, so that a tx is not lost when one node's mempool is full or its peers are lagging.
+	BroadcastToAllNodes: false,
+	ConfirmPollPeriod:   time.Second,
+	// Confirmed and Errored msgs are deleted once they are older than their retention period,
+	// in batches of up to ReaperBatchSize every ReaperPollPeriod. A retention of 0 keeps them forever.
+	// Simulated msgs are kept for ConfirmedRetention.
+	ConfirmedRetention: 24 * time.Hour,
+	ErroredRetention:   7 * 24 * time.Hour,
+	FallbackGasPrice:   sdk.MustNewDecFromStr("0.015"),
+	// If set, tx fees are paid by FeeGranter through x/feegrant instead of by the sending keys,
+	// which must each have an allowance. The allowances are reported unhealthy when they fall
+	// below FeeGrantMinAllowance of the GasToken.
+	FeeGranter:           "",
+	FeeGrantMinAllowance: sdk.MustNewDecFromStr("0"),
+	// When a tx times out waiting to be confirmed, it is re-signed at a higher gas price and
+	// rebroadcast. Each bump raises the previous price by the larger of GasBumpPercent and GasBumpMin,
+	// never going past MaxGasPrice. After MaxGasBumpAttempts rebroadcasts the msgs are marked errored.
+	// Estimated gas prices are capped at MaxGasPrice too. A MaxGasPrice of 0 means no cap.
+	GasBumpMin:         sdk.MustNewDecFromStr("0.001"),
+	GasBumpPercent:     20,
+	MaxGasBumpAttempts: 3,
+	// MaxGasPerTx and MaxTxBytes limit the gas limit and size of each tx, batches which exceed them are split into
+	// several txs. If 0, the block max gas and max bytes from the chain's consensus params are used.
+	MaxGasPerTx: 0,
+	MaxGasPrice: sdk.MustNewDecFromStr("0"),
+	// If GasPriceBlockHistory is set, gas prices are estimated from the fees paid in that many recent
+	// blocks, at GasPricePercentile of the prices paid per unit of gas. Otherwise they come from the
+	// chain's fee market module or the node's minimum gas prices, falling back to FallbackGasPrice.
+	GasPriceBlockHistory: 0,
+	GasPricePercentile:   60,
 	// This is high since we simulate before signing the transaction.
 	// There's a chicken and egg problem: need to sign to simulate accurately
 	// but you need to specify a gas limit when signing.
@@ -42,58 +77,140 @@ var defaultConfigSet = configSet{
 	// To be conservative and since the number of messages we'd
 	// have in a batch on average roughly corresponds to the number of terra ocr jobs we're running (do not expect more than 100),
 	// we can set a max msgs per batch of 100.
-	MaxMsgsPerBatch:     100,
+	MaxMsgsPerBatch: 100,
+	// Sequence numbers are tracked locally, so several txs per sender can be broadcast without
+	// waiting for the previous ones to be confirmed. Raising this increases throughput for
+	// senders with many jobs, at the cost of more txs to rebroadcast when one times out.
+	// It also bounds how many of the txs of a split batch are broadcast at once: with 1, the rest
+	// of the batch waits for the first tx to be confirmed.
+	MaxInFlightTxs:      1,
+	MaxTxBytes:          0,
 	OCR2CachePollPeriod: 4 * time.Second,
 	OCR2CacheTTL:        time.Minute,
-	TxMsgTimeout:        10 * time.Minute,
-	Bech32Prefix:        "wasm",  // note: this shouldn't be used outside of tests
-	GasToken:            "ucosm", // note: this shouldn't be used outside of tests
+	ReaperBatchSize:     1000,
+	ReaperPollPeriod:    10 * time.Minute,
+	// In ShadowMode, msgs are batched, simulated and signed as usual, but never broadcast. Msgs which succeed in
+	// simulation are marked Simulated with the gas used by their batch, so that a node or contract can be trialled
+	// without spending funds or competing with other transmitters.
+	ShadowMode:   false,
+	TxMsgTimeout: 10 * time.Minute,
+	Bech32Prefix: "wasm",  // note: this shouldn't be used outside of tests
+	GasToken:     "ucosm", // note: this shouldn't be used outside of tests
 }
 
 type Config interface {
+	BalanceMinTransmissions() int64
+	BalancePollPeriod() time.Duration
+	BalanceTransmissionGas() int64
 	Bech32Prefix() string
 	BlockRate() time.Duration
 	BlocksUntilTxTimeout() int64
+	BroadcastToAllNodes() bool
 	ConfirmPollPeriod() time.Duration
+	ConfirmedRetention() time.Duration
+	ErroredRetention() time.Duration
 	FallbackGasPrice() sdk.Dec
+	FeeGrantMinAllowance() sdk.Dec
+	FeeGranter() string
+	GasBumpMin() sdk.Dec
+	GasBumpPercent() uint16
+	GasPriceBlockHistory() int64
+	GasPricePercentile() uint16
 	GasToken() string
 	GasLimitMultiplier() float64
+	MaxGasBumpAttempts() int64
+	MaxGasPerTx() int64
+	MaxGasPrice() sdk.Dec
+	MaxInFlightTxs() int64
 	MaxMsgsPerBatch() int64
+	MaxTxBytes() int64
 	OCR2CachePollPeriod() time.Duration
 	OCR2CacheTTL() time.Duration
+	ReaperBatchSize() int64
+	ReaperPollPeriod() time.Duration
+	ShadowMode() bool
 	TxMsgTimeout() time.Duration
 }
 
 // opt: remove
 type configSet struct {
-	Bech32Prefix         string
-	BlockRate            time.Duration
-	BlocksUntilTxTimeout int64
-	ConfirmPollPeriod    time.Duration
-	FallbackGasPrice     sdk.Dec
-	GasToken             string
-	GasLimitMultiplier   float64
-	MaxMsgsPerBatch      int64
-	OCR2CachePollPeriod  time.Duration
-	OCR2CacheTTL         time.Duration
-	TxMsgTimeout         time.Duration
+	BalanceMinTransmissions int64
+	BalancePollPeriod       time.Duration
+	BalanceTransmissionGas  int64
+	Bech32Prefix            string
+	BlockRate               time.Duration
+	BlocksUntilTxTimeout    int64
+	BroadcastToAllNodes     bool
+	ConfirmPollPeriod       time.Duration
+	ConfirmedRetention      time.Duration
+	ErroredRetention        time.Duration
+	FallbackGasPrice        sdk.Dec
+	FeeGrantMinAllowance    sdk.Dec
+	FeeGranter              string
+	GasBumpMin              sdk.Dec
+	GasBumpPercent          uint16
+	GasPriceBlockHistory    int64
+	GasPricePercentile      uint16
+	GasToken                string
+	GasLimitMultiplier      float64
+	MaxGasBumpAttempts      int64
+	MaxGasPerTx             int64
+	MaxGasPrice             sdk.Dec
+	MaxInFlightTxs          int64
+	MaxMsgsPerBatch         int64
+	MaxTxBytes              int64
+	OCR2CachePollPeriod     time.Duration
+	OCR2CacheTTL            time.Duration
+	ReaperBatchSize         int64
+	ReaperPollPeriod        time.Duration
+	ShadowMode              bool
+	TxMsgTimeout            time.Duration
 }
 
 type Chain struct {
-	Bech32Prefix         *string
-	BlockRate            *config.Duration
-	BlocksUntilTxTimeout *int64
-	ConfirmPollPeriod    *config.Duration
-	FallbackGasPrice     *decimal.Decimal
-	GasToken             *string
-	GasLimitMultiplier   *decimal.Decimal
-	MaxMsgsPerBatch      *int64
-	OCR2CachePollPeriod  *config.Duration
-	OCR2CacheTTL         *config.Duration
-	TxMsgTimeout         *config.Duration
+	BalanceMinTransmissions *int64
+	BalancePollPeriod       *config.Duration
+	BalanceTransmissionGas  *int64
+	Bech32Prefix            *string
+	BlockRate               *config.Duration
+	BlocksUntilTxTimeout    *int64
+	BroadcastToAllNodes     *bool
+	ConfirmPollPeriod       *config.Duration
+	ConfirmedRetention      *config.Duration
+	ErroredRetention        *config.Duration
+	FallbackGasPrice        *decimal.Decimal
+	FeeGrantMinAllowance    *decimal.Decimal
+	FeeGranter              *string
+	GasBumpMin              *decimal.Decimal
+	GasBumpPercent          *uint16
+	GasPriceBlockHistory    *int64
+	GasPricePercentile      *uint16
+	GasToken                *string
+	GasLimitMultiplier      *decimal.Decimal
+	MaxGasBumpAttempts      *int64
+	MaxGasPerTx             *int64
+	MaxGasPrice             *decimal.Decimal
+	MaxInFlightTxs          *int64
+	MaxMsgsPerBatch         *int64
+	MaxTxBytes              *int64
+	OCR2CachePollPeriod     *config.Duration
+	OCR2CacheTTL            *config.Duration
+	ReaperBatchSize         *int64
+	ReaperPollPeriod        *config.Duration
+	ShadowMode              *bool
+	TxMsgTimeout            *config.Duration
 }
 
 func (c *Chain) SetDefaults() {
+	if c.BalanceMinTransmissions == nil {
+		c.BalanceMinTransmissions = &defaultConfigSet.BalanceMinTransmissions
+	}
+	if c.BalancePollPeriod == nil {
+		c.BalancePollPeriod = config.MustNewDuration(defaultConfigSet.BalancePollPeriod)
+	}
+	if c.BalanceTransmissionGas == nil {
+		c.BalanceTransmissionGas = &defaultConfigSet.BalanceTransmissionGas
+	}
 	if c.Bech32Prefix == nil {
 		c.Bech32Prefix = &defaultConfigSet.Bech32Prefix
 	}
@@ -103,13 +220,42 @@ func (c *Chain) SetDefaults() {
 	if c.BlocksUntilTxTimeout == nil {
 		c.BlocksUntilTxTimeout = &defaultConfigSet.BlocksUntilTxTimeout
 	}
+	if c.BroadcastToAllNodes == nil {
+		c.BroadcastToAllNodes = &defaultConfigSet.BroadcastToAllNodes
+	}
 	if c.ConfirmPollPeriod == nil {
 		c.ConfirmPollPeriod = config.MustNewDuration(defaultConfigSet.ConfirmPollPeriod)
 	}
+	if c.ConfirmedRetention == nil {
+		c.ConfirmedRetention = config.MustNewDuration(defaultConfigSet.ConfirmedRetention)
+	}
+	if c.ErroredRetention == nil {
+		c.ErroredRetention = config.MustNewDuration(defaultConfigSet.ErroredRetention)
+	}
 	if c.FallbackGasPrice == nil {
 		d := decimal.NewFromBigInt(defaultConfigSet.FallbackGasPrice.BigInt(), -sdk.Precision)
 		c.FallbackGasPrice = &d
 	}
+	if c.FeeGrantMinAllowance == nil {
+		d := decimal.NewFromBigInt(defaultConfigSet.FeeGrantMinAllowance.BigInt(), -sdk.Precision)
+		c.FeeGrantMinAllowance = &d
+	}
+	if c.FeeGranter == nil {
+		c.FeeGranter = &defaultConfigSet.FeeGranter
+	}
+	if c.GasBumpMin == nil {
+		d := decimal.NewFromBigInt(defaultConfigSet.GasBumpMin.BigInt(), -sdk.Precision)
+		c.GasBumpMin = &d
+	}
+	if c.GasBumpPercent == nil {
+		c.GasBumpPercent = &defaultConfigSet.GasBumpPercent
+	}
+	if c.GasPriceBlockHistory == nil {
+		c.GasPriceBlockHistory = &defaultConfigSet.GasPriceBlockHistory
+	}
+	if c.GasPricePercentile == nil {
+		c.GasPricePercentile = &defaultConfigSet.GasPricePercentile
+	}
 	if c.GasToken == nil {
 		c.GasToken = &defaultConfigSet.GasToken
 	}
@@ -117,15 +263,40 @@ func (c *Chain) SetDefaults() {
 		d := decimal.NewFromFloat(defaultConfigSet.GasLimitMultiplier)
 		c.GasLimitMultiplier = &d
 	}
+	if c.MaxGasBumpAttempts == nil {
+		c.MaxGasBumpAttempts = &defaultConfigSet.MaxGasBumpAttempts
+	}
+	if c.MaxGasPerTx == nil {
+		c.MaxGasPerTx = &defaultConfigSet.MaxGasPerTx
+	}
+	if c.MaxGasPrice == nil {
+		d := decimal.NewFromBigInt(defaultConfigSet.MaxGasPrice.BigInt(), -sdk.Precision)
+		c.MaxGasPrice = &d
+	}
+	if c.MaxInFlightTxs == nil {
+		c.MaxInFlightTxs = &defaultConfigSet.MaxInFlightTxs
+	}
 	if c.MaxMsgsPerBatch == nil {
 		c.MaxMsgsPerBatch = &defaultConfigSet.MaxMsgsPerBatch
 	}
+	if c.MaxTxBytes == nil {
+		c.MaxTxBytes = &defaultConfigSet.MaxTxBytes
+	}
 	if c.OCR2CachePollPeriod == nil {
 		c.OCR2CachePollPeriod = config.MustNewDuration(defaultConfigSet.OCR2CachePollPeriod)
 	}
 	if c.OCR2CacheTTL == nil {
 		c.OCR2CacheTTL = config.MustNewDuration(defaultConfigSet.OCR2CacheTTL)
 	}
+	if c.ReaperBatchSize == nil {
+		c.ReaperBatchSize = &defaultConfigSet.ReaperBatchSize
+	}
+	if c.ReaperPollPeriod == nil {
+		c.ReaperPollPeriod = config.MustNewDuration(defaultConfigSet.ReaperPollPeriod)
+	}
+	if c.ShadowMode == nil {
+		c.ShadowMode = &defaultConfigSet.ShadowMode
+	}
 	if c.TxMsgTimeout == nil {
 		c.TxMsgTimeout = config.MustNewDuration(defaultConfigSet.TxMsgTimeout)
 	}
@@ -134,6 +305,8 @@ func (c *Chain) SetDefaults() {
 type Node struct {
 	Name          *string
 	TendermintURL *config.URL
+	// SendOnly nodes are only used to broadcast txs, which are sent to them in addition to the node picked to send through.
+	SendOnly *bool
 }
 
 func (n *Node) ValidateConfig() (err error) {
@@ -227,6 +400,9 @@ func setFromNode(n, f *Node) {
 	if f.TendermintURL != nil {
 		n.TendermintURL = f.TendermintURL
 	}
+	if f.SendOnly != nil {
+		n.SendOnly = f.SendOnly
+	}
 }
 
 func legacyNode(n *Node, id string) db.Node {
@@ -234,6 +410,7 @@ func legacyNode(n *Node, id string) db.Node {
 		Name:          *n.Name,
 		CosmosChainID: id,
 		TendermintURL: (*url.URL)(n.TendermintURL).String(),
+		SendOnly:      n.SendOnly != nil && *n.SendOnly,
 	}
 }
 
@@ -261,6 +438,15 @@ func (c *TOMLConfig) SetFrom(f *TOMLConfig) {
 }
 
 func setFromChain(c, f *Chain) {
+	if f.BalanceMinTransmissions != nil {
+		c.BalanceMinTransmissions = f.BalanceMinTransmissions
+	}
+	if f.BalancePollPeriod != nil {
+		c.BalancePollPeriod = f.BalancePollPeriod
+	}
+	if f.BalanceTransmissionGas != nil {
+		c.BalanceTransmissionGas = f.BalanceTransmissionGas
+	}
 	if f.Bech32Prefix != nil {
 		c.Bech32Prefix = f.Bech32Prefix
 	}
@@ -270,27 +456,78 @@ func setFromChain(c, f *Chain) {
 	if f.BlocksUntilTxTimeout != nil {
 		c.BlocksUntilTxTimeout = f.BlocksUntilTxTimeout
 	}
+	if f.BroadcastToAllNodes != nil {
+		c.BroadcastToAllNodes = f.BroadcastToAllNodes
+	}
 	if f.ConfirmPollPeriod != nil {
 		c.ConfirmPollPeriod = f.ConfirmPollPeriod
 	}
+	if f.ConfirmedRetention != nil {
+		c.ConfirmedRetention = f.ConfirmedRetention
+	}
+	if f.ErroredRetention != nil {
+		c.ErroredRetention = f.ErroredRetention
+	}
 	if f.FallbackGasPrice != nil {
 		c.FallbackGasPrice = f.FallbackGasPrice
 	}
+	if f.FeeGrantMinAllowance != nil {
+		c.FeeGrantMinAllowance = f.FeeGrantMinAllowance
+	}
+	if f.FeeGranter != nil {
+		c.FeeGranter = f.FeeGranter
+	}
+	if f.GasBumpMin != nil {
+		c.GasBumpMin = f.GasBumpMin
+	}
+	if f.GasBumpPercent != nil {
+		c.GasBumpPercent = f.GasBumpPercent
+	}
+	if f.GasPriceBlockHistory != nil {
+		c.GasPriceBlockHistory = f.GasPriceBlockHistory
+	}
+	if f.GasPricePercentile != nil {
+		c.GasPricePercentile = f.GasPricePercentile
+	}
 	if f.GasToken != nil {
 		c.GasToken = f.GasToken
 	}
 	if f.GasLimitMultiplier != nil {
 		c.GasLimitMultiplier = f.GasLimitMultiplier
 	}
+	if f.MaxGasBumpAttempts != nil {
+		c.MaxGasBumpAttempts = f.MaxGasBumpAttempts
+	}
+	if f.MaxGasPerTx != nil {
+		c.MaxGasPerTx = f.MaxGasPerTx
+	}
+	if f.MaxGasPrice != nil {
+		c.MaxGasPrice = f.MaxGasPrice
+	}
+	if f.MaxInFlightTxs != nil {
+		c.MaxInFlightTxs = f.MaxInFlightTxs
+	}
 	if f.MaxMsgsPerBatch != nil {
 		c.MaxMsgsPerBatch = f.MaxMsgsPerBatch
 	}
+	if f.MaxTxBytes != nil {
+		c.MaxTxBytes = f.MaxTxBytes
+	}
 	if f.OCR2CachePollPeriod != nil {
 		c.OCR2CachePollPeriod = f.OCR2CachePollPeriod
 	}
 	if f.OCR2CacheTTL != nil {
 		c.OCR2CacheTTL = f.OCR2CacheTTL
 	}
+	if f.ReaperBatchSize != nil {
+		c.ReaperBatchSize = f.ReaperBatchSize
+	}
+	if f.ReaperPollPeriod != nil {
+		c.ReaperPollPeriod = f.ReaperPollPeriod
+	}
+	if f.ShadowMode != nil {
+		c.ShadowMode = f.ShadowMode
+	}
 	if f.TxMsgTimeout != nil {
 		c.TxMsgTimeout = f.TxMsgTimeout
 	}
@@ -303,8 +540,30 @@ func (c *TOMLConfig) ValidateConfig() (err error) {
 		err = errors.Join(err, config.ErrEmpty{Name: "ChainID", Msg: "required for all chains"})
 	}
 
+	if c.Chain.GasPriceBlockHistory != nil && *c.Chain.GasPriceBlockHistory < 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: "GasPriceBlockHistory", Value: *c.Chain.GasPriceBlockHistory, Msg: "must not be negative"})
+	}
+	if c.Chain.GasPricePercentile != nil && *c.Chain.GasPricePercentile > 100 {
+		err = errors.Join(err, config.ErrInvalid{Name: "GasPricePercentile", Value: *c.Chain.GasPricePercentile, Msg: "must be at most 100"})
+	}
+	if c.Chain.MaxGasPrice != nil {
+		if c.Chain.MaxGasPrice.IsNegative() {
+			err = errors.Join(err, config.ErrInvalid{Name: "MaxGasPrice", Value: *c.Chain.MaxGasPrice, Msg: "must not be negative"})
+		} else if c.Chain.FallbackGasPrice != nil && c.Chain.MaxGasPrice.IsPositive() && c.Chain.MaxGasPrice.LessThan(*c.Chain.FallbackGasPrice) {
+			err = errors.Join(err, config.ErrInvalid{Name: "MaxGasPrice", Value: *c.Chain.MaxGasPrice, Msg: "must not be less than FallbackGasPrice"})
+		}
+	}
+	if c.Chain.MaxGasPerTx != nil && *c.Chain.MaxGasPerTx < 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: "MaxGasPerTx", Value: *c.Chain.MaxGasPerTx, Msg: "must not be negative"})
+	}
+	if c.Chain.MaxTxBytes != nil && *c.Chain.MaxTxBytes < 0 {
+		err = errors.Join(err, config.ErrInvalid{Name: "MaxTxBytes", Value: *c.Chain.MaxTxBytes, Msg: "must not be negative"})
+	}
+
 	if len(c.Nodes) == 0 {
 		err = errors.Join(err, config.ErrMissing{Name: "Nodes", Msg: "must have at least one node"})
+	} else if !slices.ContainsFunc(c.Nodes, func(n *Node) bool { return n.SendOnly == nil || !*n.SendOnly }) {
+		err = errors.Join(err, config.ErrInvalid{Name: "Nodes", Value: len(c.Nodes), Msg: "must have at least one node which is not SendOnly"})
 	}
 
 	return
@@ -320,6 +579,18 @@ func (c *TOMLConfig) TOMLString() (string, error) {
 
 var _ Config = &TOMLConfig{}
 
+func (c *TOMLConfig) BalanceMinTransmissions() int64 {
+	return *c.Chain.BalanceMinTransmissions
+}
+
+func (c *TOMLConfig) BalancePollPeriod() time.Duration {
+	return c.Chain.BalancePollPeriod.Duration()
+}
+
+func (c *TOMLConfig) BalanceTransmissionGas() int64 {
+	return *c.Chain.BalanceTransmissionGas
+}
+
 func (c *TOMLConfig) Bech32Prefix() string {
 	return *c.Chain.Bech32Prefix
 }
@@ -332,14 +603,50 @@ func (c *TOMLConfig) BlocksUntilTxTimeout() int64 {
 	return *c.Chain.BlocksUntilTxTimeout
 }
 
+func (c *TOMLConfig) BroadcastToAllNodes() bool {
+	return *c.Chain.BroadcastToAllNodes
+}
+
 func (c *TOMLConfig) ConfirmPollPeriod() time.Duration {
 	return c.Chain.ConfirmPollPeriod.Duration()
 }
 
+func (c *TOMLConfig) ConfirmedRetention() time.Duration {
+	return c.Chain.ConfirmedRetention.Duration()
+}
+
+func (c *TOMLConfig) ErroredRetention() time.Duration {
+	return c.Chain.ErroredRetention.Duration()
+}
+
 func (c *TOMLConfig) FallbackGasPrice() sdk.Dec {
 	return sdkDecFromDecimal(c.Chain.FallbackGasPrice)
 }
 
+func (c *TOMLConfig) FeeGrantMinAllowance() sdk.Dec {
+	return sdkDecFromDecimal(c.Chain.FeeGrantMinAllowance)
+}
+
+func (c *TOMLConfig) FeeGranter() string {
+	return *c.Chain.FeeGranter
+}
+
+func (c *TOMLConfig) GasBumpMin() sdk.Dec {
+	return sdkDecFromDecimal(c.Chain.GasBumpMin)
+}
+
+func (c *TOMLConfig) GasBumpPercent() uint16 {
+	return *c.Chain.GasBumpPercent
+}
+
+func (c *TOMLConfig) GasPriceBlockHistory() int64 {
+	return *c.Chain.GasPriceBlockHistory
+}
+
+func (c *TOMLConfig) GasPricePercentile() uint16 {
+	return *c.Chain.GasPricePercentile
+}
+
 func (c *TOMLConfig) GasToken() string {
 	return *c.Chain.GasToken
 }
@@ -348,10 +655,30 @@ func (c *TOMLConfig) GasLimitMultiplier() float64 {
 	return c.Chain.GasLimitMultiplier.InexactFloat64()
 }
 
+func (c *TOMLConfig) MaxGasBumpAttempts() int64 {
+	return *c.Chain.MaxGasBumpAttempts
+}
+
+func (c *TOMLConfig) MaxGasPerTx() int64 {
+	return *c.Chain.MaxGasPerTx
+}
+
+func (c *TOMLConfig) MaxGasPrice() sdk.Dec {
+	return sdkDecFromDecimal(c.Chain.MaxGasPrice)
+}
+
+func (c *TOMLConfig) MaxInFlightTxs() int64 {
+	return *c.Chain.MaxInFlightTxs
+}
+
 func (c *TOMLConfig) MaxMsgsPerBatch() int64 {
 	return *c.Chain.MaxMsgsPerBatch
 }
 
+func (c *TOMLConfig) MaxTxBytes() int64 {
+	return *c.Chain.MaxTxBytes
+}
+
 func (c *TOMLConfig) OCR2CachePollPeriod() time.Duration {
 	return c.Chain.OCR2CachePollPeriod.Duration()
 }
@@ -360,6 +687,18 @@ func (c *TOMLConfig) OCR2CacheTTL() time.Duration {
 	return c.Chain.OCR2CacheTTL.Duration()
 }
 
+func (c *TOMLConfig) ReaperBatchSize() int64 {
+	return *c.Chain.ReaperBatchSize
+}
+
+func (c *TOMLConfig) ReaperPollPeriod() time.Duration {
+	return c.Chain.ReaperPollPeriod.Duration()
+}
+
+func (c *TOMLConfig) ShadowMode() bool {
+	return *c.Chain.ShadowMode
+}
+
 func (c *TOMLConfig) TxMsgTimeout() time.Duration {
 	return c.Chain.TxMsgTimeout.Duration()
 }
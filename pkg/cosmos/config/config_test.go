@@ -91,6 +91,36 @@ func TestCosmosConfig_GetNode(t *testing.T) {
 	}
 }
 
+func TestTOMLConfig_ValidateConfig_MaxGasPrice(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		maxGasPrice string
+		wantErr     bool
+	}{
+		{name: "no cap", maxGasPrice: "0"},
+		{name: "above fallback", maxGasPrice: "0.2"},
+		{name: "equal to fallback", maxGasPrice: "0.015"},
+		{name: "below fallback", maxGasPrice: "0.01", wantErr: true},
+		{name: "negative", maxGasPrice: "-1", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			maxGasPrice := decimal.RequireFromString(tt.maxGasPrice)
+			c := &TOMLConfig{
+				ChainID: ptr("chainID"),
+				Chain:   Chain{MaxGasPrice: &maxGasPrice},
+				Nodes:   Nodes{{Name: ptr("node"), TendermintURL: &config.URL{}}},
+			}
+			c.SetDefaults()
+			err := c.ValidateConfig()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "MaxGasPrice")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func ptr[T any](t T) *T {
 	return &t
 }
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"slices"
 	"time"
 )
 
@@ -9,6 +10,7 @@ type Node struct {
 	Name          string
 	CosmosChainID string
 	TendermintURL string `db:"tendermint_url"`
+	SendOnly      bool
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -19,34 +21,92 @@ type State string
 
 var (
 	// Unstarted means queued but not processed.
-	// Valid next states: Started, Errored (cancelled)
+	// Valid next states: Started, Errored (cancelled or expired)
 	Unstarted State = "unstarted"
 	// Started means included in a batch about to be broadcast.
-	// Valid next states: Broadcasted, Errored (sim fails)
+	// Valid next states: Broadcasted, Errored (sim fails, cancelled or expired), Simulated (ShadowMode)
 	Started State = "started"
 	// Broadcasted means included in the mempool of a node.
-	// Valid next states: Confirmed (found onchain), Errored (tx expired waiting for confirmation)
+	// Valid next states: Confirmed (found onchain), Broadcasted (rebroadcast with a bumped gas price),
+	// Errored (tx expired waiting for confirmation)
 	Broadcasted State = "broadcasted"
 	// Confirmed means we're able to retrieve the txhash of the tx which broadcasted the msg.
 	// Valid next states: none, terminal state
 	Confirmed State = "confirmed"
 	// Errored means the msg:
 	//  - reverted in simulation
-	//  - the tx containing the message timed out waiting to be confirmed
-	//  - the msg was cancelled
-	// TODO: when we add gas bumping, we'll address that timeout case
+	//  - the tx containing the message timed out waiting to be confirmed, and we either ran out of
+	//    gas bump attempts or could not bump the gas price any further
+	//  - the tx containing the message was included but failed to execute
+	//  - the msg was cancelled, by a newer msg or by an operator
+	//  - the msg expired waiting to be broadcast
+	//  - the msg was too large to be sent
+	// See Reason for which.
 	// Valid next states, none, terminal state
 	Errored State = "errored"
+	// Simulated means the msg succeeded in simulation and was signed, but was not broadcast because
+	// the chain is in ShadowMode. SimulatedGas records the gas used by its batch.
+	// Valid next states: none, terminal state
+	Simulated State = "simulated"
+)
+
+// IsTerminal returns true if s has no valid next states.
+func (s State) IsTerminal() bool {
+	return s == Confirmed || s == Errored || s == Simulated
+}
+
+// transitions are the valid next states of each non-terminal state, as documented above.
+// The database enforces the same transitions, see migrations/0007_add_state_constraints.sql.
+var transitions = map[State][]State{
+	Unstarted:   {Started, Errored},
+	Started:     {Broadcasted, Errored, Simulated},
+	Broadcasted: {Broadcasted, Confirmed, Errored},
+}
+
+// CanTransitionTo returns true if a msg in state s can be moved to next.
+func (s State) CanTransitionTo(next State) bool {
+	return slices.Contains(transitions[s], next)
+}
+
+// Reason records why a msg ended up in its current state.
+type Reason string
+
+var (
+	// Replaced means the msg was cancelled while Unstarted because a newer msg was enqueued for the same contract.
+	Replaced Reason = "replaced"
+	// Coalesced means the msg was cancelled while Unstarted because a newer msg was enqueued for the same
+	// contract with the same coalesce key.
+	Coalesced Reason = "coalesced"
+	// Expired means the msg was not broadcast within TxMsgTimeout.
+	Expired Reason = "expired"
+	// SimulationFailed means the msg reverted in simulation.
+	SimulationFailed Reason = "simulation_failed"
+	// TimedOut means the tx containing the msg was not included before it timed out.
+	TimedOut Reason = "timed_out"
+	// ExecutionFailed means the tx containing the msg was included but failed, e.g. ran out of gas.
+	ExecutionFailed Reason = "execution_failed"
+	// Cancelled means the msg was cancelled by an operator before it was broadcast.
+	Cancelled Reason = "cancelled"
+	// Oversized means the msg alone exceeds the gas or byte limit of a tx, so it can never be sent.
+	Oversized Reason = "oversized"
 )
 
 type Msg struct {
-	ID         int64
-	ChainID    string `db:"cosmos_chain_id"`
-	ContractID string
-	State      State
-	Type       string // cosmos-sdk/types.MsgTypeURL()
-	Raw        []byte // proto.Marshal()
-	TxHash     *string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID          int64
+	ChainID     string `db:"cosmos_chain_id"`
+	ContractID  string
+	CoalesceKey *string // optional, set when enqueued with a coalesce key
+	State       State
+	Reason      *Reason // optional, why the msg was moved to State
+	ErrorCode   *uint32 // optional, ABCI code of the failure when Errored
+	ErrorLog    *string // optional, log of the failure when Errored
+	Type        string  // cosmos-sdk/types.MsgTypeURL()
+	Raw         []byte  // proto.Marshal()
+	TxHash      *string
+	// TimeoutHeight is the last height at which the tx can be included, set when Broadcasted.
+	TimeoutHeight *int64
+	// SimulatedGas is the gas used by the simulated batch, set when Simulated.
+	SimulatedGas *int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
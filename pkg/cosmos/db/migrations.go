@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+)
+
+// Migrations are the goose compatible SQL migrations of the cosmos_msgs table, in MigrationsDir.
+// Hosts must run them before creating a Txm, recording the applied versions in MigrationsTable, e.g.:
+//
+//	goose.SetTableName(db.MigrationsTable)
+//	goose.SetBaseFS(db.Migrations)
+//	goose.Up(sqlDB, db.MigrationsDir)
+//
+// The migrations apply over a cosmos_msgs table created by the core node's own migrations too, so running them
+// baselines it. See docs/DatabaseMigrations.md.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+const (
+	// MigrationsDir is the directory of Migrations which contains the SQL files.
+	MigrationsDir = "migrations"
+	// MigrationsTable is the goose version table which records the applied Migrations.
+	// It is separate from the host's own migrations table, since the versions are independent.
+	MigrationsTable = "goose_migrations_cosmos"
+	// SchemaVersion is the version of the latest migration, which the Txm requires.
+	SchemaVersion int64 = 7
+)
+
+// ErrUnversionedSchema is returned by CheckSchemaVersion when the cosmos_msgs table exists, but MigrationsTable
+// does not, e.g. because the table was created by the core node's own migrations before they moved here.
+var ErrUnversionedSchema = errors.New("cosmos_msgs exists but the cosmos migrations have not been run")
+
+// CheckSchemaVersion returns an error unless the Migrations have been applied to ds, up to at least SchemaVersion.
+// Returns ErrUnversionedSchema if the Migrations were never run over an existing cosmos_msgs table.
+func CheckSchemaVersion(ctx context.Context, ds sqlutil.DataSource) error {
+	var versioned, exists bool
+	if err := ds.GetContext(ctx, &versioned, `SELECT to_regclass($1) IS NOT NULL`, MigrationsTable); err != nil {
+		return fmt.Errorf("failed to check for %s: %w", MigrationsTable, err)
+	}
+	if !versioned {
+		if err := ds.GetContext(ctx, &exists, `SELECT to_regclass('cosmos_msgs') IS NOT NULL`); err != nil {
+			return fmt.Errorf("failed to check for cosmos_msgs: %w", err)
+		}
+		if exists {
+			return ErrUnversionedSchema
+		}
+		return fmt.Errorf("%s not found, run the cosmos migrations", MigrationsTable)
+	}
+	var version int64
+	if err := ds.GetContext(ctx, &version, `SELECT COALESCE(MAX(version_id), 0) FROM `+MigrationsTable+` WHERE is_applied`); err != nil {
+		return fmt.Errorf("failed to get cosmos schema version from %s, have the cosmos migrations been run? %w", MigrationsTable, err)
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("cosmos schema version %d is older than the required version %d, run the cosmos migrations", version, SchemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- The table was previously created by the core node, so this is a no-op for existing databases.
+CREATE TABLE IF NOT EXISTS cosmos_msgs (
+    id BIGSERIAL PRIMARY KEY,
+    cosmos_chain_id TEXT NOT NULL,
+    contract_id TEXT NOT NULL,
+    state TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT '/cosmwasm.wasm.v1.MsgExecuteContract',
+    raw BYTEA NOT NULL,
+    tx_hash TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_cosmos_msgs_cosmos_chain_id_state ON cosmos_msgs (cosmos_chain_id, state);
+CREATE INDEX IF NOT EXISTS idx_cosmos_msgs_cosmos_chain_id_contract_id_state ON cosmos_msgs (cosmos_chain_id, contract_id, state);
+
+-- +goose Down
+DROP TABLE IF EXISTS cosmos_msgs;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE cosmos_msgs
+    ADD COLUMN IF NOT EXISTS coalesce_key TEXT,
+    ADD COLUMN IF NOT EXISTS reason TEXT;
+
+-- +goose Down
+ALTER TABLE cosmos_msgs
+    DROP COLUMN IF EXISTS coalesce_key,
+    DROP COLUMN IF EXISTS reason;
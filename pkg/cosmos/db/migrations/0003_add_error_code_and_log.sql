@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE cosmos_msgs
+    ADD COLUMN IF NOT EXISTS error_code BIGINT,
+    ADD COLUMN IF NOT EXISTS error_log TEXT;
+
+-- +goose Down
+ALTER TABLE cosmos_msgs
+    DROP COLUMN IF EXISTS error_code,
+    DROP COLUMN IF EXISTS error_log;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- The reaper deletes terminal msgs by when they were last updated
+CREATE INDEX IF NOT EXISTS idx_cosmos_msgs_cosmos_chain_id_state_updated_at ON cosmos_msgs (cosmos_chain_id, state, updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cosmos_msgs_cosmos_chain_id_state_updated_at;
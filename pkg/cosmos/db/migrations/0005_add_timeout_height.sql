@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cosmos_msgs ADD COLUMN IF NOT EXISTS timeout_height BIGINT;
+
+-- +goose Down
+ALTER TABLE cosmos_msgs DROP COLUMN IF EXISTS timeout_height;
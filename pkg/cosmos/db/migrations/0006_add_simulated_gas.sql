@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cosmos_msgs ADD COLUMN IF NOT EXISTS simulated_gas BIGINT;
+
+-- +goose Down
+ALTER TABLE cosmos_msgs DROP COLUMN IF EXISTS simulated_gas;
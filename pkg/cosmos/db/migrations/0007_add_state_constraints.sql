@@ -0,0 +1,34 @@
+-- +goose Up
+-- Replaces the check from the core node, which compared tx_hash <> NULL and so never failed.
+ALTER TABLE cosmos_msgs DROP CONSTRAINT IF EXISTS cosmos_msgs_check;
+ALTER TABLE cosmos_msgs
+    ADD CONSTRAINT cosmos_msgs_state_check
+        CHECK (state IN ('unstarted', 'started', 'broadcasted', 'confirmed', 'errored', 'simulated')),
+    ADD CONSTRAINT cosmos_msgs_tx_hash_check
+        CHECK (tx_hash IS NOT NULL OR state NOT IN ('broadcasted', 'confirmed'));
+
+-- Keep in sync with db.State.CanTransitionTo
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION check_cosmos_msg_state_transition() RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT (
+        (OLD.state = 'unstarted' AND NEW.state IN ('started', 'errored')) OR
+        (OLD.state = 'started' AND NEW.state IN ('broadcasted', 'errored', 'simulated')) OR
+        (OLD.state = 'broadcasted' AND NEW.state IN ('broadcasted', 'confirmed', 'errored'))
+    ) THEN
+        RAISE EXCEPTION 'invalid cosmos msg state transition from % to % for msg %', OLD.state, NEW.state, OLD.id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER cosmos_msgs_state_transition BEFORE UPDATE OF state ON cosmos_msgs
+    FOR EACH ROW EXECUTE FUNCTION check_cosmos_msg_state_transition();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS cosmos_msgs_state_transition ON cosmos_msgs;
+DROP FUNCTION IF EXISTS check_cosmos_msg_state_transition();
+ALTER TABLE cosmos_msgs
+    DROP CONSTRAINT IF EXISTS cosmos_msgs_state_check,
+    DROP CONSTRAINT IF EXISTS cosmos_msgs_tx_hash_check;
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	entries, err := fs.ReadDir(Migrations, MigrationsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		require.True(t, ok, "migration %s has no version prefix", e.Name())
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i+1), version, "migration versions must be sequential")
+
+		b, err := fs.ReadFile(Migrations, path.Join(MigrationsDir, e.Name()))
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "-- +goose Up")
+		assert.Contains(t, string(b), "-- +goose Down")
+	}
+	assert.Equal(t, int64(len(entries)), SchemaVersion, "SchemaVersion must be the latest migration")
+}
+
+func TestState_CanTransitionTo(t *testing.T) {
+	states := []State{Unstarted, Started, Broadcasted, Confirmed, Errored, Simulated}
+	for _, s := range states {
+		var next []State
+		for _, n := range states {
+			if s.CanTransitionTo(n) {
+				next = append(next, n)
+			}
+		}
+		assert.Equal(t, s.IsTerminal(), len(next) == 0, s)
+	}
+	assert.True(t, Broadcasted.CanTransitionTo(Broadcasted), "rebroadcast")
+	assert.False(t, Started.CanTransitionTo(Confirmed))
+	assert.False(t, Errored.CanTransitionTo(Unstarted))
+}
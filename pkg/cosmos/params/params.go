@@ -11,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 )
 
 // encodingConfig specifies the concrete encoding types to use for a given app.
@@ -73,6 +74,8 @@ func initCosmosSdk(bech32Prefix, token string) {
 	std.RegisterInterfaces(config.InterfaceRegistry)
 	// needed for Client.Account() to deserialize authtypes.AccountI
 	authtypes.RegisterInterfaces(config.InterfaceRegistry)
+	// needed for Client.FeeAllowance() to deserialize feegrant.FeeAllowanceI
+	feegrant.RegisterInterfaces(config.InterfaceRegistry)
 
 	sdkConfig := sdk.GetConfig()
 	sdkConfig.SetBech32PrefixForAccount(bech32PrefixAccAddr, bech32PrefixAccPub)
@@ -0,0 +1,153 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+)
+
+// feeGrantCheckPeriod is how often fee allowances are checked.
+const feeGrantCheckPeriod = time.Minute
+
+// feeGrantChecker periodically checks that every key has a fee allowance from the configured FeeGranter,
+// with at least FeeGrantMinAllowance left to spend.
+type feeGrantChecker struct {
+	lggr logger.SugaredLogger
+	cfg  config.Config
+	tc   func() (client.ReaderWriter, error)
+	ks   *keystoreAdapter
+
+	mu  sync.RWMutex
+	err error
+}
+
+func newFeeGrantChecker(tc func() (client.ReaderWriter, error), ks *keystoreAdapter, cfg config.Config, lggr logger.Logger) *feeGrantChecker {
+	return &feeGrantChecker{
+		lggr: logger.Sugared(logger.Named(lggr, "FeeGrant")),
+		cfg:  cfg,
+		tc:   tc,
+		ks:   ks,
+	}
+}
+
+func (c *feeGrantChecker) name() string { return c.lggr.Name() }
+
+// healthy returns an error if any allowance was missing or low as of the last check.
+func (c *feeGrantChecker) healthy() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+// run checks the allowances every feeGrantCheckPeriod until ctx is done.
+func (c *feeGrantChecker) run(ctx context.Context) {
+	if c.cfg.FeeGranter() == "" {
+		return
+	}
+	tick := time.After(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			err := c.check(ctx)
+			if err != nil {
+				c.lggr.Warnw("fee grant check failed", "err", err)
+			}
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			tick = time.After(utils.WithJitter(feeGrantCheckPeriod))
+		}
+	}
+}
+
+func (c *feeGrantChecker) check(ctx context.Context) error {
+	granter, err := sdk.AccAddressFromBech32(c.cfg.FeeGranter())
+	if err != nil {
+		return fmt.Errorf("invalid fee granter: %w", err)
+	}
+	tc, err := c.tc()
+	if err != nil {
+		return err
+	}
+	accounts, err := c.ks.Accounts(ctx)
+	if err != nil {
+		return err
+	}
+	denom := c.cfg.GasToken()
+	minAllowance := c.cfg.FeeGrantMinAllowance()
+	var merr error
+	for _, account := range accounts {
+		grantee, err := sdk.AccAddressFromBech32(account)
+		if err != nil {
+			merr = errors.Join(merr, err)
+			continue
+		}
+		allowance, err := tc.FeeAllowance(ctx, granter, grantee)
+		if err != nil {
+			merr = errors.Join(merr, fmt.Errorf("unable to get fee allowance for %s: %w", account, err))
+			continue
+		}
+		spendable, err := spendableAllowance(allowance, denom, time.Now())
+		if err != nil {
+			merr = errors.Join(merr, fmt.Errorf("fee allowance for %s: %w", account, err))
+			continue
+		}
+		if spendable == nil {
+			continue // unlimited
+		}
+		c.lggr.Debugw("fee allowance", "grantee", account, "spendable", spendable.String(), "denom", denom)
+		if spendable.IsZero() || sdk.NewDecFromInt(*spendable).LT(minAllowance) {
+			merr = errors.Join(merr, fmt.Errorf("fee allowance for %s is low: %s%s", account, spendable, denom))
+		}
+	}
+	return merr
+}
+
+// spendableAllowance returns how much of denom can still be spent from allowance, or nil if it is unlimited.
+func spendableAllowance(allowance feegrant.FeeAllowanceI, denom string, now time.Time) (*sdk.Int, error) {
+	switch a := allowance.(type) {
+	case *feegrant.BasicAllowance:
+		if a.Expiration != nil && !a.Expiration.After(now) {
+			return nil, fmt.Errorf("expired at %s", a.Expiration)
+		}
+		if a.SpendLimit.Empty() {
+			return nil, nil
+		}
+		amount := a.SpendLimit.AmountOf(denom)
+		return &amount, nil
+	case *feegrant.PeriodicAllowance:
+		basic, err := spendableAllowance(&a.Basic, denom, now)
+		if err != nil {
+			return nil, err
+		}
+		period := a.PeriodCanSpend.AmountOf(denom)
+		if !now.Before(a.PeriodReset) {
+			// The period resets on the next use
+			period = a.PeriodSpendLimit.AmountOf(denom)
+		}
+		if basic != nil && basic.LT(period) {
+			return basic, nil
+		}
+		return &period, nil
+	case *feegrant.AllowedMsgAllowance:
+		inner, err := a.GetAllowance()
+		if err != nil {
+			return nil, err
+		}
+		return spendableAllowance(inner, denom, now)
+	}
+	return nil, fmt.Errorf("unsupported allowance type %T", allowance)
+}
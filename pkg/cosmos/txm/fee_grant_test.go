@@ -0,0 +1,72 @@
+package txm
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendableAllowance(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	coins := func(amt int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ucosm", amt)) }
+	basic := func(amt int64, exp *time.Time) feegrant.BasicAllowance {
+		b := feegrant.BasicAllowance{Expiration: exp}
+		if amt >= 0 {
+			b.SpendLimit = coins(amt)
+		}
+		return b
+	}
+
+	for _, tt := range []struct {
+		name      string
+		allowance feegrant.FeeAllowanceI
+		exp       *int64 // nil for unlimited
+		expErr    bool
+	}{
+		{name: "basic", allowance: ptr(basic(100, nil)), exp: ptr[int64](100)},
+		{name: "basic unlimited", allowance: ptr(basic(-1, &future))},
+		{name: "basic expired", allowance: ptr(basic(100, &past)), expErr: true},
+		{name: "basic other denom", allowance: &feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("uatom", 5))}, exp: ptr[int64](0)},
+		{name: "periodic", allowance: &feegrant.PeriodicAllowance{
+			Basic: basic(100, nil), PeriodSpendLimit: coins(50), PeriodCanSpend: coins(10), PeriodReset: future,
+		}, exp: ptr[int64](10)},
+		{name: "periodic reset", allowance: &feegrant.PeriodicAllowance{
+			Basic: basic(100, nil), PeriodSpendLimit: coins(50), PeriodCanSpend: coins(10), PeriodReset: past,
+		}, exp: ptr[int64](50)},
+		{name: "periodic basic limit", allowance: &feegrant.PeriodicAllowance{
+			Basic: basic(5, nil), PeriodSpendLimit: coins(50), PeriodCanSpend: coins(10), PeriodReset: future,
+		}, exp: ptr[int64](5)},
+		{name: "periodic unlimited basic", allowance: &feegrant.PeriodicAllowance{
+			Basic: basic(-1, nil), PeriodSpendLimit: coins(50), PeriodCanSpend: coins(10), PeriodReset: future,
+		}, exp: ptr[int64](10)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spendableAllowance(tt.allowance, "ucosm", now)
+			if tt.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.exp == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, sdk.NewInt(*tt.exp).String(), got.String())
+		})
+	}
+
+	t.Run("allowed msgs", func(t *testing.T) {
+		a, err := feegrant.NewAllowedMsgAllowance(ptr(basic(7, nil)), []string{"/cosmwasm.wasm.v1.MsgExecuteContract"})
+		require.NoError(t, err)
+		got, err := spendableAllowance(a, "ucosm", now)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "7", got.String())
+	})
+}
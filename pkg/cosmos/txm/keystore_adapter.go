@@ -12,6 +12,7 @@ import (
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"golang.org/x/crypto/ripemd160" //nolint: staticcheck
+	"golang.org/x/exp/maps"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
 )
@@ -37,6 +38,19 @@ func newKeystoreAdapter(keystore loop.Keystore, accountPrefix string) *keystoreA
 	}
 }
 
+// Accounts returns the bech32 addresses of all accounts in the keystore.
+func (ka *keystoreAdapter) Accounts(ctx context.Context) ([]string, error) {
+	ka.mutex.Lock()
+	defer ka.mutex.Unlock()
+	err := ka.updateMappingLocked(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := maps.Keys(ka.addressToPubKey)
+
+	return addresses, nil
+}
+
 func (ka *keystoreAdapter) updateMappingLocked(ctx context.Context) error {
 	accounts, err := ka.keystore.Accounts(ctx)
 	if err != nil {
@@ -0,0 +1,279 @@
+package txm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+// MemoryStorage is a Storage which keeps msgs in memory, so they are lost on restart.
+// Transactions are serialized with all other calls.
+type MemoryStorage struct {
+	chainID string
+	mu      *sync.Mutex
+	data    *memoryData
+	inTx    bool // set on the Storage passed to a Transaction, which already holds mu
+}
+
+type memoryData struct {
+	nextID int64
+	msgs   map[int64]db.Msg
+}
+
+func (d *memoryData) clone() *memoryData {
+	c := &memoryData{nextID: d.nextID, msgs: make(map[int64]db.Msg, len(d.msgs))}
+	for id, m := range d.msgs {
+		c.msgs[id] = m
+	}
+	return c
+}
+
+// NewMemoryStorage returns an empty MemoryStorage for chainID.
+func NewMemoryStorage(chainID string) *MemoryStorage {
+	return &MemoryStorage{
+		chainID: chainID,
+		mu:      new(sync.Mutex),
+		data:    &memoryData{nextID: 1, msgs: map[int64]db.Msg{}},
+	}
+}
+
+// lock locks s and returns the func to unlock it, unless s is already locked by a Transaction.
+func (s *MemoryStorage) lock() func() {
+	if s.inTx {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *MemoryStorage) Transaction(ctx context.Context, fn func(Storage) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.data.clone()
+	if err := fn(&MemoryStorage{chainID: s.chainID, mu: s.mu, data: s.data, inTx: true}); err != nil {
+		*s.data = *snapshot
+		return err
+	}
+	return nil
+}
+
+// find returns copies of the msgs of this chain which match, ordered by id.
+func (s *MemoryStorage) find(match func(db.Msg) bool) adapters.Msgs {
+	var ids []int64
+	for id, m := range s.data.msgs {
+		if m.ChainID == s.chainID && match(m) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	msgs := make(adapters.Msgs, len(ids))
+	for i, id := range ids {
+		msgs[i] = adapters.Msg{Msg: s.data.msgs[id]}
+	}
+	return msgs
+}
+
+// update calls fn on each msg with one of ids, and returns the ids which were found, ordered by id.
+// Like the database, it fails without updating any msgs if fn makes an invalid state transition.
+func (s *MemoryStorage) update(ids []int64, fn func(*db.Msg)) ([]int64, error) {
+	now := time.Now()
+	updated := map[int64]db.Msg{}
+	for _, id := range ids {
+		m, ok := s.data.msgs[id]
+		if _, dup := updated[id]; !ok || dup {
+			continue
+		}
+		from := m.State
+		fn(&m)
+		if !from.CanTransitionTo(m.State) {
+			return nil, fmt.Errorf("invalid cosmos msg state transition from %s to %s for msg %d", from, m.State, id)
+		}
+		m.UpdatedAt = now
+		updated[id] = m
+	}
+	updatedIDs := make([]int64, 0, len(updated))
+	for id, m := range updated {
+		s.data.msgs[id] = m
+		updatedIDs = append(updatedIDs, id)
+	}
+	slices.Sort(updatedIDs)
+	return updatedIDs, nil
+}
+
+// updateAll is like update, but returns an error if any of ids were not found.
+func (s *MemoryStorage) updateAll(ids []int64, fn func(*db.Msg)) error {
+	updated, err := s.update(ids, fn)
+	if err != nil {
+		return err
+	}
+	if len(updated) != len(ids) {
+		return fmt.Errorf("expected %d records updated, got %d", len(ids), len(updated))
+	}
+	return nil
+}
+
+func getIDs(msgs adapters.Msgs) []int64 {
+	ids := make([]int64, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+func (s *MemoryStorage) InsertMsg(ctx context.Context, contractID, typeURL string, msg []byte) (int64, error) {
+	return s.InsertMsgWithKey(ctx, contractID, nil, typeURL, msg)
+}
+
+func (s *MemoryStorage) InsertMsgWithKey(ctx context.Context, contractID string, coalesceKey *string, typeURL string, msg []byte) (int64, error) {
+	defer s.lock()()
+	return s.insert(db.Msg{ContractID: contractID, CoalesceKey: coalesceKey, Type: typeURL, Raw: bytes.Clone(msg)}), nil
+}
+
+// insert adds m as a new Unstarted msg, and returns its id.
+func (s *MemoryStorage) insert(m db.Msg) int64 {
+	now := time.Now()
+	m.ID = s.data.nextID
+	m.ChainID = s.chainID
+	m.State = db.Unstarted
+	m.CreatedAt, m.UpdatedAt = now, now
+	s.data.msgs[m.ID] = m
+	s.data.nextID++
+	return m.ID
+}
+
+func (s *MemoryStorage) UpdateMsgsContract(ctx context.Context, contractID string, from, to db.State) error {
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool { return m.ContractID == contractID && m.State == from })
+	_, err := s.update(getIDs(msgs), func(m *db.Msg) { m.State = to })
+	return err
+}
+
+func (s *MemoryStorage) CancelUnstartedMsgs(ctx context.Context, contractID string, coalesceKey *string, reason db.Reason) ([]int64, error) {
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool {
+		return m.ContractID == contractID && m.State == db.Unstarted &&
+			(coalesceKey == nil || (m.CoalesceKey != nil && *m.CoalesceKey == *coalesceKey))
+	})
+	return s.update(getIDs(msgs), func(m *db.Msg) {
+		m.State = db.Errored
+		m.Reason = &reason
+	})
+}
+
+func (s *MemoryStorage) GetMsgsState(ctx context.Context, state db.State, limit int64) (adapters.Msgs, error) {
+	if limit < 1 {
+		return adapters.Msgs{}, errors.New("limit must be greater than 0")
+	}
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool { return m.State == state })
+	return msgs[:min(int64(len(msgs)), limit)], nil
+}
+
+func (s *MemoryStorage) GetMsgs(ctx context.Context, ids ...int64) (adapters.Msgs, error) {
+	defer s.lock()()
+	return s.find(func(m db.Msg) bool { return slices.Contains(ids, m.ID) }), nil
+}
+
+func (s *MemoryStorage) UpdateMsgsErrored(ctx context.Context, ids []int64, reason db.Reason, errCode *uint32, errLog *string) error {
+	defer s.lock()()
+	return s.updateAll(ids, func(m *db.Msg) {
+		m.State = db.Errored
+		m.Reason, m.ErrorCode, m.ErrorLog = &reason, errCode, errLog
+	})
+}
+
+func (s *MemoryStorage) UpdateMsgs(ctx context.Context, ids []int64, state db.State, txHash *string) error {
+	if state == db.Broadcasted && txHash == nil {
+		return errors.New("txHash is required when updating to broadcasted")
+	}
+	defer s.lock()()
+	return s.updateAll(ids, func(m *db.Msg) {
+		m.State = state
+		if state == db.Broadcasted {
+			m.TxHash = txHash
+		}
+	})
+}
+
+func (s *MemoryStorage) UpdateMsgsBroadcasted(ctx context.Context, ids []int64, txHash string, timeoutHeight int64) error {
+	defer s.lock()()
+	return s.updateAll(ids, func(m *db.Msg) {
+		m.State = db.Broadcasted
+		m.TxHash, m.TimeoutHeight = &txHash, &timeoutHeight
+	})
+}
+
+func (s *MemoryStorage) UpdateMsgsSimulated(ctx context.Context, ids []int64, gasUsed int64) error {
+	defer s.lock()()
+	return s.updateAll(ids, func(m *db.Msg) {
+		m.State = db.Simulated
+		m.SimulatedGas = &gasUsed
+	})
+}
+
+func (s *MemoryStorage) DeleteMsgsBefore(ctx context.Context, state db.State, cutoff time.Time, limit int64) (int64, error) {
+	if limit < 1 {
+		return 0, errors.New("limit must be greater than 0")
+	}
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool { return m.State == state && m.UpdatedAt.Before(cutoff) })
+	msgs = msgs[:min(int64(len(msgs)), limit)]
+	for _, m := range msgs {
+		delete(s.data.msgs, m.ID)
+	}
+	return int64(len(msgs)), nil
+}
+
+func (s *MemoryStorage) CountMsgsByState(ctx context.Context) (map[db.State]int64, error) {
+	defer s.lock()()
+	counts := map[db.State]int64{}
+	for _, m := range s.find(func(db.Msg) bool { return true }) {
+		counts[m.State]++
+	}
+	return counts, nil
+}
+
+func (s *MemoryStorage) ListMsgs(ctx context.Context, f MsgFilter) (adapters.Msgs, error) {
+	if f.Limit < 1 {
+		return adapters.Msgs{}, errors.New("limit must be greater than 0")
+	}
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool {
+		return (len(f.States) == 0 || slices.Contains(f.States, m.State)) &&
+			(f.ContractID == "" || m.ContractID == f.ContractID) &&
+			(f.CreatedAfter.IsZero() || !m.CreatedAt.Before(f.CreatedAfter)) &&
+			(f.CreatedBefore.IsZero() || m.CreatedAt.Before(f.CreatedBefore))
+	})
+	return msgs[:min(int64(len(msgs)), f.Limit)], nil
+}
+
+func (s *MemoryStorage) CancelMsgs(ctx context.Context, ids []int64) ([]int64, error) {
+	defer s.lock()()
+	msgs := s.find(func(m db.Msg) bool {
+		return slices.Contains(ids, m.ID) && (m.State == db.Unstarted || m.State == db.Started)
+	})
+	reason := db.Cancelled
+	return s.update(getIDs(msgs), func(m *db.Msg) {
+		m.State = db.Errored
+		m.Reason = &reason
+	})
+}
+
+func (s *MemoryStorage) ResubmitMsgs(ctx context.Context, ids []int64) ([]int64, error) {
+	defer s.lock()()
+	var resubmitted []int64
+	for _, m := range s.find(func(m db.Msg) bool { return slices.Contains(ids, m.ID) && m.State == db.Errored }) {
+		resubmitted = append(resubmitted, s.insert(db.Msg{ContractID: m.ContractID, CoalesceKey: m.CoalesceKey, Type: m.Type, Raw: m.Raw}))
+	}
+	return resubmitted, nil
+}
@@ -0,0 +1,46 @@
+package txm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	cosmosdb "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+func TestMemoryStorage_Transaction(t *testing.T) {
+	ctx := tests.Context(t)
+	s := NewMemoryStorage(RandomChainID())
+	id, err := s.InsertMsg(ctx, "0x123", "", []byte("hello"))
+	require.NoError(t, err)
+
+	// Rolled back on error
+	errRollback := errors.New("rollback")
+	err = s.Transaction(ctx, func(tx Storage) error {
+		_, err := tx.InsertMsg(ctx, "0x123", "", []byte("world"))
+		require.NoError(t, err)
+		require.NoError(t, tx.UpdateMsgs(ctx, []int64{id}, cosmosdb.Started, nil))
+		// Nested transactions are part of the outer one
+		return tx.Transaction(ctx, func(Storage) error { return errRollback })
+	})
+	require.ErrorIs(t, err, errRollback)
+	counts, err := s.CountMsgsByState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[cosmosdb.State]int64{cosmosdb.Unstarted: 1}, counts)
+
+	// Committed otherwise
+	var id2 int64
+	require.NoError(t, s.Transaction(ctx, func(tx Storage) (err error) {
+		id2, err = tx.InsertMsg(ctx, "0x123", "", []byte("world"))
+		return err
+	}))
+	assert.Greater(t, id2, id)
+	msgs, err := s.GetMsgs(ctx, id, id2)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "world", string(msgs[1].Raw))
+}
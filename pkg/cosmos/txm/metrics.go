@@ -0,0 +1,102 @@
+package txm
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+var (
+	promMsgs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cosmos_txm_msgs",
+		Help: "Number of msgs in each state",
+	}, []string{"chainID", "state"})
+	promBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cosmos_txm_batch_size",
+		Help:    "Number of msgs in each broadcast tx",
+		Buckets: []float64{1, 2, 5, 10, 20, 50, 100},
+	}, []string{"chainID"})
+	promSimulationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cosmos_txm_simulation_failures",
+		Help: "Number of msgs which failed simulation",
+	}, []string{"chainID"})
+	promBroadcastErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cosmos_txm_broadcast_errors",
+		Help: "Number of txs which could not be broadcast, by ABCI codespace and code. Errors without a code have an empty codespace.",
+	}, []string{"chainID", "codespace", "code"})
+	promGasUsedRatio = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cosmos_txm_gas_used_ratio",
+		Help:    "Gas used by each included tx, as a fraction of its gas limit",
+		Buckets: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+	}, []string{"chainID"})
+	promFeesPaid = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cosmos_txm_fees_paid",
+		Help: "Fees paid for included txs by each sender",
+	}, []string{"chainID", "sender", "denom"})
+	promConfirmLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cosmos_txm_confirm_latency_seconds",
+		Help:    "Time from each msg being enqueued to it being confirmed",
+		Buckets: []float64{1, 2, 5, 10, 20, 30, 60, 120, 300, 600},
+	}, []string{"chainID"})
+)
+
+// allStates are the states which msgs are counted in.
+var allStates = []db.State{db.Unstarted, db.Started, db.Broadcasted, db.Confirmed, db.Errored, db.Simulated}
+
+// txmMetrics records the prometheus metrics of a Txm.
+type txmMetrics struct {
+	chainID string
+}
+
+// setMsgCounts sets the number of msgs in each state. States missing from counts have none.
+func (m txmMetrics) setMsgCounts(counts map[db.State]int64) {
+	for _, s := range allStates {
+		promMsgs.WithLabelValues(m.chainID, string(s)).Set(float64(counts[s]))
+	}
+}
+
+func (m txmMetrics) observeSimulation(results *client.BatchSimResults) {
+	promSimulationFailures.WithLabelValues(m.chainID).Add(float64(len(results.Failed)))
+}
+
+func (m txmMetrics) observeBatch(size int) {
+	promBatchSize.WithLabelValues(m.chainID).Observe(float64(size))
+}
+
+func (m txmMetrics) incBroadcastErrors(err error) {
+	var codespace, code string
+	var txErr *client.TxError
+	if errors.As(err, &txErr) {
+		codespace, code = txErr.Codespace, strconv.FormatUint(uint64(txErr.Code), 10)
+	}
+	promBroadcastErrors.WithLabelValues(m.chainID, codespace, code).Inc()
+}
+
+// observeIncluded records the gas used and fees paid by tx, which was sent by sender.
+func (m txmMetrics) observeIncluded(sender string, tx *txtypes.GetTxResponse) {
+	if wanted := tx.TxResponse.GasWanted; wanted > 0 {
+		promGasUsedRatio.WithLabelValues(m.chainID).Observe(float64(tx.TxResponse.GasUsed) / float64(wanted))
+	}
+	if sender == "" || tx.Tx == nil || tx.Tx.AuthInfo == nil || tx.Tx.AuthInfo.Fee == nil {
+		return
+	}
+	for _, c := range tx.Tx.AuthInfo.Fee.Amount {
+		f, _ := c.Amount.BigInt().Float64()
+		promFeesPaid.WithLabelValues(m.chainID, sender, c.Denom).Add(f)
+	}
+}
+
+// observeConfirmed records how long each of msgs took to be confirmed.
+func (m txmMetrics) observeConfirmed(msgs adapters.Msgs, now time.Time) {
+	for _, msg := range msgs {
+		promConfirmLatency.WithLabelValues(m.chainID).Observe(now.Sub(msg.CreatedAt).Seconds())
+	}
+}
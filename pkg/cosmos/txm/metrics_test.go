@@ -0,0 +1,40 @@
+package txm
+
+import (
+	"errors"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+func TestTxmMetrics(t *testing.T) {
+	chainID := RandomChainID()
+	m := txmMetrics{chainID: chainID}
+
+	m.setMsgCounts(map[db.State]int64{db.Unstarted: 3, db.Confirmed: 7})
+	assert.Equal(t, float64(3), testutil.ToFloat64(promMsgs.WithLabelValues(chainID, string(db.Unstarted))))
+	assert.Equal(t, float64(7), testutil.ToFloat64(promMsgs.WithLabelValues(chainID, string(db.Confirmed))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(promMsgs.WithLabelValues(chainID, string(db.Broadcasted))))
+
+	m.incBroadcastErrors(&client.TxError{Codespace: "sdk", Code: 20})
+	m.incBroadcastErrors(errors.New("connection refused"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(promBroadcastErrors.WithLabelValues(chainID, "sdk", "20")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(promBroadcastErrors.WithLabelValues(chainID, "", "")))
+
+	sender := "wasm1sender"
+	tx := &txtypes.GetTxResponse{
+		Tx: &txtypes.Tx{AuthInfo: &txtypes.AuthInfo{Fee: &txtypes.Fee{
+			Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("ucosm", 150)),
+		}}},
+		TxResponse: &cosmostypes.TxResponse{GasWanted: 1000, GasUsed: 800},
+	}
+	m.observeIncluded(sender, tx)
+	m.observeIncluded(sender, tx)
+	assert.Equal(t, float64(300), testutil.ToFloat64(promFeesPaid.WithLabelValues(chainID, sender, "ucosm")))
+}
@@ -0,0 +1,59 @@
+package txm
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+)
+
+// msgEventsBufferSize is the number of events buffered per subscriber before events are dropped.
+const msgEventsBufferSize = 100
+
+// msgEvents fans out msg state transitions to subscribers.
+type msgEvents struct {
+	lggr logger.SugaredLogger
+
+	mu   sync.Mutex
+	subs map[chan adapters.MsgEvent]struct{}
+}
+
+func newMsgEvents(lggr logger.SugaredLogger) *msgEvents {
+	return &msgEvents{lggr: lggr, subs: make(map[chan adapters.MsgEvent]struct{})}
+}
+
+func (e *msgEvents) subscribe() (<-chan adapters.MsgEvent, func()) {
+	ch := make(chan adapters.MsgEvent, msgEventsBufferSize)
+	e.mu.Lock()
+	e.subs[ch] = struct{}{}
+	e.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			e.mu.Lock()
+			delete(e.subs, ch)
+			e.mu.Unlock()
+		})
+	}
+}
+
+// publish sends a copy of e for each of ids to all subscribers, without blocking.
+// Must only be called once the state transition is committed.
+func (e *msgEvents) publish(ids []int64, event adapters.MsgEvent) {
+	if len(ids) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs {
+		for _, id := range ids {
+			event.ID = id
+			select {
+			case ch <- event:
+			default:
+				e.lggr.Warnw("subscriber is not keeping up, dropping msg event", "id", id, "state", event.State)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package txm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+func TestMsgEvents(t *testing.T) {
+	e := newMsgEvents(logger.Sugared(logger.Test(t)))
+	ch1, unsubscribe1 := e.subscribe()
+	ch2, unsubscribe2 := e.subscribe()
+	defer unsubscribe2()
+
+	txHash := "ABC"
+	e.publish([]int64{1, 2}, adapters.MsgEvent{State: db.Broadcasted, TxHash: &txHash})
+	for _, ch := range []<-chan adapters.MsgEvent{ch1, ch2} {
+		require.Len(t, ch, 2)
+		assert.Equal(t, adapters.MsgEvent{ID: 1, State: db.Broadcasted, TxHash: &txHash}, <-ch)
+		assert.Equal(t, adapters.MsgEvent{ID: 2, State: db.Broadcasted, TxHash: &txHash}, <-ch)
+	}
+
+	unsubscribe1()
+	unsubscribe1()
+	e.publish([]int64{1}, adapters.MsgEvent{State: db.Confirmed, TxHash: &txHash, Height: 10})
+	assert.Empty(t, ch1)
+	require.Len(t, ch2, 1)
+	assert.Equal(t, adapters.MsgEvent{ID: 1, State: db.Confirmed, TxHash: &txHash, Height: 10}, <-ch2)
+
+	// Slow subscribers don't block
+	ids := make([]int64, msgEventsBufferSize+1)
+	e.publish(ids, adapters.MsgEvent{State: db.Started})
+	assert.Len(t, ch2, msgEventsBufferSize)
+}
@@ -0,0 +1,171 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+)
+
+// errTooManyInFlight is returned when an account already has the max number of unconfirmed txs.
+var errTooManyInFlight = errors.New("too many txs in flight")
+
+// sequenceMismatchRe matches the error returned by the auth ante handler when a tx uses the wrong sequence.
+var sequenceMismatchRe = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+// isSequenceMismatch returns true if err was caused by a tx using the wrong sequence number.
+// If the node reported the sequence it expected, that is returned too.
+func isSequenceMismatch(err error) (mismatch bool, expected uint64, ok bool) {
+	if !errors.Is(err, client.ErrSequenceMismatch) {
+		return false, 0, false
+	}
+	m := sequenceMismatchRe.FindStringSubmatch(err.Error())
+	if len(m) != 3 {
+		return true, 0, false
+	}
+	expected, perr := strconv.ParseUint(m[1], 10, 64)
+	if perr != nil {
+		return true, 0, false
+	}
+	return true, expected, true
+}
+
+// accountNonce is the local sequence tracking state of a single account.
+type accountNonce struct {
+	number   uint64 // account number
+	next     uint64 // next sequence to hand out
+	inFlight int64  // sequences handed out and not yet released
+	// stale means next can not be trusted, e.g. after a tx timed out without being included.
+	// The account is resynced from chain once it has no txs in flight.
+	stale bool
+}
+
+// nonceManager hands out account sequence numbers locally, so that several txs from
+// the same account can be in flight at once rather than waiting for each to be confirmed.
+// Sequences are read from chain the first time an account is used, and resynced when a
+// node reports a sequence mismatch.
+type nonceManager struct {
+	lggr        logger.SugaredLogger
+	maxInFlight int64
+
+	mu       sync.Mutex
+	accounts map[string]*accountNonce
+}
+
+func newNonceManager(maxInFlight int64, lggr logger.Logger) *nonceManager {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+	return &nonceManager{
+		lggr:        logger.Sugared(logger.Named(lggr, "NonceManager")),
+		maxInFlight: maxInFlight,
+		accounts:    make(map[string]*accountNonce),
+	}
+}
+
+// reserve returns the account number and the next sequence number for sender, which must later be
+// passed to release. Returns errTooManyInFlight if sender already has the max number of txs in flight.
+func (nm *nonceManager) reserve(ctx context.Context, tc client.Reader, sender sdk.AccAddress) (uint64, uint64, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	from := sender.String()
+	acc, ok := nm.accounts[from]
+	if !ok || (acc.stale && acc.inFlight == 0) {
+		// Holding the lock while reading the account is fine, this is rare.
+		an, sn, err := tc.Account(ctx, sender)
+		if err != nil {
+			return 0, 0, err
+		}
+		nm.lggr.Debugw("synced sequence from chain", "from", from, "seqnum", sn)
+		acc = &accountNonce{number: an, next: sn}
+		nm.accounts[from] = acc
+	}
+	if acc.stale || acc.inFlight >= nm.maxInFlight {
+		return 0, 0, errTooManyInFlight
+	}
+	sn := acc.next
+	acc.next++
+	acc.inFlight++
+	return acc.number, sn, nil
+}
+
+// release returns a sequence obtained from reserve. If the sequence was not used by a tx which
+// made it into a mempool, it is handed out again if possible, otherwise the account is marked stale.
+func (nm *nonceManager) release(sender sdk.AccAddress, sn uint64, used bool) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	acc, ok := nm.accounts[sender.String()]
+	if !ok {
+		return
+	}
+	acc.inFlight--
+	if used {
+		return
+	}
+	if sn+1 == acc.next {
+		acc.next = sn
+		return
+	}
+	// A later sequence was handed out already, so there's now a gap
+	acc.stale = true
+}
+
+// resync handles a sequence mismatch error for sender. Returns false if err is not a sequence mismatch.
+func (nm *nonceManager) resync(sender sdk.AccAddress, err error) bool {
+	mismatch, expected, ok := isSequenceMismatch(err)
+	if !mismatch {
+		return false
+	}
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	from := sender.String()
+	acc, found := nm.accounts[from]
+	if !found {
+		return true
+	}
+	switch {
+	case !ok:
+		acc.stale = true
+	case expected > acc.next:
+		// Sequences were used outside of this node, skip ahead
+		nm.lggr.Warnw("sequence mismatch, skipping ahead", "from", from, "expected", expected, "next", acc.next)
+		acc.next = expected
+	case expected < acc.next && acc.inFlight == 0:
+		nm.lggr.Warnw("sequence mismatch, rewinding", "from", from, "expected", expected, "next", acc.next)
+		acc.next = expected
+	default:
+		// The node has not seen our in flight txs yet, leave the sequence as is.
+		nm.lggr.Debugw("sequence mismatch with txs in flight", "from", from, "expected", expected, "next", acc.next, "inFlight", acc.inFlight)
+	}
+	return true
+}
+
+// inFlight returns the number of sequences handed out to sender and not yet released.
+func (nm *nonceManager) inFlight(sender string) int64 {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	if acc, ok := nm.accounts[sender]; ok {
+		return acc.inFlight
+	}
+	return 0
+}
+
+// full returns the senders which can not reserve another sequence until some are released.
+func (nm *nonceManager) full() []string {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	var senders []string
+	for from, acc := range nm.accounts {
+		if acc.inFlight >= nm.maxInFlight || (acc.stale && acc.inFlight > 0) {
+			senders = append(senders, from)
+		}
+	}
+	return senders
+}
@@ -0,0 +1,109 @@
+package txm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client/mocks"
+)
+
+func TestNonceManager(t *testing.T) {
+	lggr := logger.Test(t)
+	sender := newKeystore(1).address(0)
+
+	t.Run("pipelines up to max in flight", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		tc.On("Account", mock.Anything, sender).Return(uint64(7), uint64(5), nil).Once()
+		nm := newNonceManager(2, lggr)
+
+		an, sn, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), an)
+		assert.Equal(t, uint64(5), sn)
+		_, sn, err = nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), sn)
+		_, _, err = nm.reserve(ctx, tc, sender)
+		require.ErrorIs(t, err, errTooManyInFlight)
+		assert.Equal(t, []string{sender.String()}, nm.full())
+
+		nm.release(sender, 5, true)
+		assert.Empty(t, nm.full())
+		_, sn, err = nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), sn)
+	})
+
+	t.Run("unused sequence is handed out again", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		tc.On("Account", mock.Anything, sender).Return(uint64(7), uint64(5), nil).Once()
+		nm := newNonceManager(2, lggr)
+
+		_, sn, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		nm.release(sender, sn, false)
+		_, sn, err = nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), sn)
+	})
+
+	t.Run("gap resyncs once drained", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		tc.On("Account", mock.Anything, sender).Return(uint64(7), uint64(5), nil).Once()
+		nm := newNonceManager(2, lggr)
+
+		_, sn1, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		_, sn2, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		// First tx timed out, so the second can never be included either
+		nm.release(sender, sn1, false)
+		_, _, err = nm.reserve(ctx, tc, sender)
+		require.ErrorIs(t, err, errTooManyInFlight)
+		assert.Equal(t, []string{sender.String()}, nm.full())
+
+		nm.release(sender, sn2, false)
+		tc.On("Account", mock.Anything, sender).Return(uint64(7), uint64(5), nil).Once()
+		_, sn, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), sn)
+	})
+
+	t.Run("resync on sequence mismatch", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		tc.On("Account", mock.Anything, sender).Return(uint64(7), uint64(5), nil).Once()
+		nm := newNonceManager(2, lggr)
+
+		assert.False(t, nm.resync(sender, client.ErrOutOfGas))
+
+		// Used outside of this node
+		_, sn, err := nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		nm.release(sender, sn, false)
+		assert.True(t, nm.resync(sender, sequenceMismatch("account sequence mismatch, expected 9, got 5: incorrect account sequence")))
+		_, sn, err = nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(9), sn)
+
+		// Lagging node which has not seen the in flight tx yet
+		assert.True(t, nm.resync(sender, sequenceMismatch("account sequence mismatch, expected 9, got 10: incorrect account sequence")))
+		_, sn, err = nm.reserve(ctx, tc, sender)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), sn)
+	})
+}
+
+func sequenceMismatch(log string) error {
+	return &client.TxError{Codespace: client.ErrSequenceMismatch.Codespace, Code: client.ErrSequenceMismatch.Code, Log: log}
+}
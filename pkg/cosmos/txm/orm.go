@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 
@@ -12,7 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
 )
 
-// ORM manages the data model for cosmos tx management.
+// ORM manages the data model for cosmos tx management, in the cosmos_msgs table of a Postgres database.
 type ORM struct {
 	chainID string
 	ds      sqlutil.DataSource
@@ -26,8 +29,8 @@ func NewORM(chainID string, ds sqlutil.DataSource) *ORM {
 	}
 }
 
-func (o *ORM) Transaction(ctx context.Context, fn func(*ORM) error) (err error) {
-	return sqlutil.Transact(ctx, o.new, o.ds, nil, fn)
+func (o *ORM) Transaction(ctx context.Context, fn func(Storage) error) (err error) {
+	return sqlutil.Transact(ctx, o.new, o.ds, nil, func(tx *ORM) error { return fn(tx) })
 }
 
 // new returns a NewORM like o, but backed by q.
@@ -35,10 +38,15 @@ func (o *ORM) new(q sqlutil.Queryer) *ORM { return NewORM(o.chainID, q) }
 
 // InsertMsg inserts a cosmos msg, assumed to be a serialized cosmos ExecuteContractMsg.
 func (o *ORM) InsertMsg(ctx context.Context, contractID, typeURL string, msg []byte) (int64, error) {
+	return o.InsertMsgWithKey(ctx, contractID, nil, typeURL, msg)
+}
+
+// InsertMsgWithKey inserts a cosmos msg like InsertMsg, with an optional coalesce key.
+func (o *ORM) InsertMsgWithKey(ctx context.Context, contractID string, coalesceKey *string, typeURL string, msg []byte) (int64, error) {
 	var tm adapters.Msg
 
-	err := o.ds.GetContext(ctx, &tm, `INSERT INTO cosmos_msgs (contract_id, type, raw, state, cosmos_chain_id, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING *`, contractID, typeURL, msg, db.Unstarted, o.chainID)
+	err := o.ds.GetContext(ctx, &tm, `INSERT INTO cosmos_msgs (contract_id, coalesce_key, type, raw, state, cosmos_chain_id, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING *`, contractID, coalesceKey, typeURL, msg, db.Unstarted, o.chainID)
 	if err != nil {
 		return 0, err
 	}
@@ -55,6 +63,19 @@ func (o *ORM) UpdateMsgsContract(ctx context.Context, contractID string, from, t
 	return nil
 }
 
+// CancelUnstartedMsgs marks the Unstarted messages for the given contract as Errored with reason, and returns their ids.
+// If coalesceKey is not nil, only messages with the same key are cancelled.
+func (o *ORM) CancelUnstartedMsgs(ctx context.Context, contractID string, coalesceKey *string, reason db.Reason) ([]int64, error) {
+	var ids []int64
+	err := o.ds.SelectContext(ctx, &ids, `UPDATE cosmos_msgs SET state = $1, reason = $2, updated_at = NOW()
+	WHERE cosmos_chain_id = $3 AND contract_id = $4 AND state = $5 AND ($6::text IS NULL OR coalesce_key = $6) RETURNING id`,
+		db.Errored, reason, o.chainID, contractID, db.Unstarted, coalesceKey)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // GetMsgsState returns the oldest messages with a given state up to limit.
 func (o *ORM) GetMsgsState(ctx context.Context, state db.State, limit int64) (adapters.Msgs, error) {
 	if limit < 1 {
@@ -67,15 +88,33 @@ func (o *ORM) GetMsgsState(ctx context.Context, state db.State, limit int64) (ad
 	return msgs, nil
 }
 
-// GetMsgs returns any messages matching ids.
+// GetMsgs returns any messages of this chain matching ids.
 func (o *ORM) GetMsgs(ctx context.Context, ids ...int64) (adapters.Msgs, error) {
 	var msgs adapters.Msgs
-	if err := o.ds.SelectContext(ctx, &msgs, `SELECT * FROM cosmos_msgs WHERE id = ANY($1)`, ids); err != nil {
+	if err := o.ds.SelectContext(ctx, &msgs, `SELECT * FROM cosmos_msgs WHERE id = ANY($1) AND cosmos_chain_id = $2`, ids, o.chainID); err != nil {
 		return nil, err
 	}
 	return msgs, nil
 }
 
+// UpdateMsgsErrored marks msgs with the given ids as Errored, recording why.
+// errCode and errLog are optional.
+func (o *ORM) UpdateMsgsErrored(ctx context.Context, ids []int64, reason db.Reason, errCode *uint32, errLog *string) error {
+	res, err := o.ds.ExecContext(ctx, `UPDATE cosmos_msgs SET state = $1, reason = $2, error_code = $3, error_log = $4, updated_at = NOW()
+	WHERE id = ANY($5)`, db.Errored, reason, errCode, errLog, ids)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return fmt.Errorf("expected %d records updated, got %d", len(ids), count)
+	}
+	return nil
+}
+
 // UpdateMsgs updates msgs with the given ids.
 // Note state transitions are validated at the db level.
 func (o *ORM) UpdateMsgs(ctx context.Context, ids []int64, state db.State, txHash *string) error {
@@ -101,3 +140,137 @@ func (o *ORM) UpdateMsgs(ctx context.Context, ids []int64, state db.State, txHas
 	}
 	return nil
 }
+
+// UpdateMsgsBroadcasted marks msgs as broadcasted in the tx with txHash, which can be included up to timeoutHeight.
+func (o *ORM) UpdateMsgsBroadcasted(ctx context.Context, ids []int64, txHash string, timeoutHeight int64) error {
+	res, err := o.ds.ExecContext(ctx, `UPDATE cosmos_msgs SET state = $1, updated_at = NOW(), tx_hash = $2, timeout_height = $3 WHERE id = ANY($4)`,
+		db.Broadcasted, txHash, timeoutHeight, ids)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return fmt.Errorf("expected %d records updated, got %d", len(ids), count)
+	}
+	return nil
+}
+
+// UpdateMsgsSimulated marks msgs as simulated in a batch which used gasUsed.
+func (o *ORM) UpdateMsgsSimulated(ctx context.Context, ids []int64, gasUsed int64) error {
+	res, err := o.ds.ExecContext(ctx, `UPDATE cosmos_msgs SET state = $1, updated_at = NOW(), simulated_gas = $2 WHERE id = ANY($3)`,
+		db.Simulated, gasUsed, ids)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return fmt.Errorf("expected %d records updated, got %d", len(ids), count)
+	}
+	return nil
+}
+
+// DeleteMsgsBefore deletes up to limit messages in the given state which were last updated before cutoff,
+// and returns the number deleted.
+func (o *ORM) DeleteMsgsBefore(ctx context.Context, state db.State, cutoff time.Time, limit int64) (int64, error) {
+	if limit < 1 {
+		return 0, errors.New("limit must be greater than 0")
+	}
+	res, err := o.ds.ExecContext(ctx, `DELETE FROM cosmos_msgs WHERE id IN (
+		SELECT id FROM cosmos_msgs WHERE cosmos_chain_id = $1 AND state = $2 AND updated_at < $3 ORDER BY id ASC LIMIT $4
+	)`, o.chainID, state, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// MsgFilter selects messages to list. Zero valued fields match any message.
+type MsgFilter struct {
+	States     []db.State
+	ContractID string
+	// CreatedAfter and CreatedBefore bound when messages were enqueued.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Limit is the maximum number of messages to return, and must be greater than 0.
+	Limit int64
+}
+
+// ListMsgs returns the oldest messages matching f, up to f.Limit.
+func (o *ORM) ListMsgs(ctx context.Context, f MsgFilter) (adapters.Msgs, error) {
+	if f.Limit < 1 {
+		return adapters.Msgs{}, errors.New("limit must be greater than 0")
+	}
+	var states []string
+	for _, s := range f.States {
+		states = append(states, string(s))
+	}
+	var after, before *time.Time
+	if !f.CreatedAfter.IsZero() {
+		after = &f.CreatedAfter
+	}
+	if !f.CreatedBefore.IsZero() {
+		before = &f.CreatedBefore
+	}
+	var msgs adapters.Msgs
+	// pq.Array keeps the query usable from plain lib/pq connections, as well as pgx.
+	if err := o.ds.SelectContext(ctx, &msgs, `SELECT * FROM cosmos_msgs WHERE cosmos_chain_id = $1
+	AND ($2::text[] IS NULL OR state::text = ANY($2::text[]))
+	AND ($3 = '' OR contract_id = $3)
+	AND ($4::timestamptz IS NULL OR created_at >= $4)
+	AND ($5::timestamptz IS NULL OR created_at < $5)
+	ORDER BY id ASC LIMIT $6`, o.chainID, pq.Array(states), f.ContractID, after, before, f.Limit); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// CancelMsgs marks any Unstarted or Started messages with the given ids as Errored with reason Cancelled,
+// and returns the ids which were cancelled. Messages in other states are left unchanged.
+// Note that Started messages may be about to be broadcast, so they should only be cancelled while the
+// Txm is stopped, or when their sender is stuck.
+func (o *ORM) CancelMsgs(ctx context.Context, ids []int64) ([]int64, error) {
+	var cancelled []int64
+	err := o.ds.SelectContext(ctx, &cancelled, `UPDATE cosmos_msgs SET state = $1, reason = $2, updated_at = NOW()
+	WHERE cosmos_chain_id = $3 AND id = ANY($4) AND state IN ($5, $6) RETURNING id`,
+		db.Errored, db.Cancelled, o.chainID, pq.Array(ids), db.Unstarted, db.Started)
+	if err != nil {
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+// ResubmitMsgs enqueues a copy of each Errored message with the given ids as a new Unstarted message,
+// and returns the ids of the new messages. Messages in other states are skipped.
+func (o *ORM) ResubmitMsgs(ctx context.Context, ids []int64) ([]int64, error) {
+	var resubmitted []int64
+	err := o.ds.SelectContext(ctx, &resubmitted, `INSERT INTO cosmos_msgs (contract_id, coalesce_key, type, raw, state, cosmos_chain_id, created_at, updated_at)
+	SELECT contract_id, coalesce_key, type, raw, $1, cosmos_chain_id, NOW(), NOW() FROM cosmos_msgs
+	WHERE cosmos_chain_id = $2 AND id = ANY($3) AND state = $4 ORDER BY id ASC RETURNING id`,
+		db.Unstarted, o.chainID, pq.Array(ids), db.Errored)
+	if err != nil {
+		return nil, err
+	}
+	return resubmitted, nil
+}
+
+// CountMsgsByState returns the number of messages in each state.
+func (o *ORM) CountMsgsByState(ctx context.Context) (map[db.State]int64, error) {
+	var rows []struct {
+		State db.State
+		Count int64
+	}
+	if err := o.ds.SelectContext(ctx, &rows, `SELECT state, COUNT(*) AS count FROM cosmos_msgs WHERE cosmos_chain_id = $1 GROUP BY state`, o.chainID); err != nil {
+		return nil, err
+	}
+	counts := make(map[db.State]int64, len(rows))
+	for _, r := range rows {
+		counts[r.State] = r.Count
+	}
+	return counts, nil
+}
@@ -2,6 +2,7 @@ package txm
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
@@ -13,64 +14,184 @@ import (
 )
 
 func TestORM(t *testing.T) {
-	ctx := tests.Context(t)
-	chainID := RandomChainID()
-	db := NewDB(t)
-	o := NewORM(chainID, db)
-
-	// Create
-	mid, err := o.InsertMsg(ctx, "0x123", "", []byte("hello"))
-	require.NoError(t, err)
-	assert.NotEqual(t, 0, int(mid))
-
-	// Read
-	unstarted, err := o.GetMsgsState(ctx, cosmosdb.Unstarted, 5)
-	require.NoError(t, err)
-	require.Equal(t, 1, len(unstarted))
-	assert.Equal(t, "hello", string(unstarted[0].Raw))
-	assert.Equal(t, chainID, unstarted[0].ChainID)
-	t.Log(unstarted[0].UpdatedAt, unstarted[0].CreatedAt)
-
-	// Limit
-	unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 0)
-	assert.Error(t, err)
-	assert.Empty(t, unstarted)
-	unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, -1)
-	assert.Error(t, err)
-	assert.Empty(t, unstarted)
-	mid2, err := o.InsertMsg(ctx, "0xabc", "", []byte("test"))
-	require.NoError(t, err)
-	assert.NotEqual(t, 0, int(mid2))
-	unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 1)
-	require.NoError(t, err)
-	require.Equal(t, 1, len(unstarted))
-	assert.Equal(t, "hello", string(unstarted[0].Raw))
-	assert.Equal(t, chainID, unstarted[0].ChainID)
-	unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 2)
-	require.NoError(t, err)
-	require.Equal(t, 2, len(unstarted))
-	assert.Equal(t, "test", string(unstarted[1].Raw))
-	assert.Equal(t, chainID, unstarted[1].ChainID)
-
-	// Update
-	txHash := "123"
-	err = o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Started, &txHash)
-	require.NoError(t, err)
-	err = o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Broadcasted, &txHash)
-	require.NoError(t, err)
-	broadcasted, err := o.GetMsgsState(ctx, cosmosdb.Broadcasted, 5)
-	require.NoError(t, err)
-	require.Equal(t, 1, len(broadcasted))
-	assert.Equal(t, broadcasted[0].Raw, unstarted[0].Raw)
-	require.NotNil(t, broadcasted[0].TxHash)
-	assert.Equal(t, *broadcasted[0].TxHash, txHash)
-	assert.Equal(t, chainID, broadcasted[0].ChainID)
-
-	err = o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Confirmed, nil)
-	require.NoError(t, err)
-	confirmed, err := o.GetMsgsState(ctx, cosmosdb.Confirmed, 5)
-	require.NoError(t, err)
-	require.Equal(t, 1, len(confirmed))
+	testStorage(t, func(t *testing.T, chainID string, o Storage) {
+		ctx := tests.Context(t)
+
+		// Create
+		mid, err := o.InsertMsg(ctx, "0x123", "", []byte("hello"))
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, int(mid))
+
+		// Read
+		unstarted, err := o.GetMsgsState(ctx, cosmosdb.Unstarted, 5)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(unstarted))
+		assert.Equal(t, "hello", string(unstarted[0].Raw))
+		assert.Equal(t, chainID, unstarted[0].ChainID)
+		t.Log(unstarted[0].UpdatedAt, unstarted[0].CreatedAt)
+
+		// Limit
+		unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 0)
+		assert.Error(t, err)
+		assert.Empty(t, unstarted)
+		unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, -1)
+		assert.Error(t, err)
+		assert.Empty(t, unstarted)
+		mid2, err := o.InsertMsg(ctx, "0xabc", "", []byte("test"))
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, int(mid2))
+		unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(unstarted))
+		assert.Equal(t, "hello", string(unstarted[0].Raw))
+		assert.Equal(t, chainID, unstarted[0].ChainID)
+		unstarted, err = o.GetMsgsState(ctx, cosmosdb.Unstarted, 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(unstarted))
+		assert.Equal(t, "test", string(unstarted[1].Raw))
+		assert.Equal(t, chainID, unstarted[1].ChainID)
+
+		// Update
+		txHash := "123"
+		err = o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Started, &txHash)
+		require.NoError(t, err)
+		err = o.UpdateMsgsBroadcasted(ctx, []int64{mid}, txHash, 42)
+		require.NoError(t, err)
+		broadcasted, err := o.GetMsgsState(ctx, cosmosdb.Broadcasted, 5)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(broadcasted))
+		assert.Equal(t, broadcasted[0].Raw, unstarted[0].Raw)
+		require.NotNil(t, broadcasted[0].TxHash)
+		assert.Equal(t, *broadcasted[0].TxHash, txHash)
+		require.NotNil(t, broadcasted[0].TimeoutHeight)
+		assert.Equal(t, int64(42), *broadcasted[0].TimeoutHeight)
+		assert.Equal(t, chainID, broadcasted[0].ChainID)
+
+		err = o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Confirmed, nil)
+		require.NoError(t, err)
+		confirmed, err := o.GetMsgsState(ctx, cosmosdb.Confirmed, 5)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(confirmed))
+
+		// Confirmed is terminal
+		require.Error(t, o.UpdateMsgs(ctx, []int64{mid}, cosmosdb.Started, nil))
+	})
+}
+
+func TestORM_DeleteMsgsBefore(t *testing.T) {
+	testStorage(t, func(t *testing.T, _ string, o Storage) {
+		ctx := tests.Context(t)
+
+		var ids []int64
+		for i := 0; i < 3; i++ {
+			id, err := o.InsertMsg(ctx, "0x123", "", []byte("hello"))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		require.NoError(t, o.UpdateMsgs(ctx, ids, cosmosdb.Started, nil))
+		require.NoError(t, o.UpdateMsgsBroadcasted(ctx, ids[:2], "0xdeadbeef", 42))
+		require.NoError(t, o.UpdateMsgs(ctx, ids[:2], cosmosdb.Confirmed, nil))
+		require.NoError(t, o.UpdateMsgsErrored(ctx, ids[2:], cosmosdb.SimulationFailed, nil, nil))
+		unstarted, err := o.InsertMsg(ctx, "0x123", "", []byte("hello"))
+		require.NoError(t, err)
+
+		counts, err := o.CountMsgsByState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[cosmosdb.State]int64{cosmosdb.Unstarted: 1, cosmosdb.Confirmed: 2, cosmosdb.Errored: 1}, counts)
+
+		// Nothing is old enough
+		deleted, err := o.DeleteMsgsBefore(ctx, cosmosdb.Confirmed, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+
+		// Bounded by limit
+		deleted, err = o.DeleteMsgsBefore(ctx, cosmosdb.Confirmed, time.Now().Add(time.Hour), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		deleted, err = o.DeleteMsgsBefore(ctx, cosmosdb.Confirmed, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = o.DeleteMsgsBefore(ctx, cosmosdb.Errored, time.Now().Add(time.Hour), 0)
+		require.Error(t, err)
+
+		msgs, err := o.GetMsgs(ctx, append(ids, unstarted)...)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		counts, err = o.CountMsgsByState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[cosmosdb.State]int64{cosmosdb.Unstarted: 1, cosmosdb.Errored: 1}, counts)
+	})
+}
+
+func TestORM_Operator(t *testing.T) {
+	testStorage(t, func(t *testing.T, _ string, o Storage) {
+		ctx := tests.Context(t)
+
+		var ids []int64
+		for _, contract := range []string{"0x123", "0x123", "0xabc"} {
+			id, err := o.InsertMsg(ctx, contract, "", []byte("hello"))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		require.NoError(t, o.UpdateMsgs(ctx, ids[1:2], cosmosdb.Started, nil))
+		require.NoError(t, o.UpdateMsgsErrored(ctx, ids[2:], cosmosdb.SimulationFailed, nil, nil))
+
+		// List
+		_, err := o.ListMsgs(ctx, MsgFilter{})
+		require.Error(t, err)
+		msgs, err := o.ListMsgs(ctx, MsgFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, msgs, 3)
+		msgs, err = o.ListMsgs(ctx, MsgFilter{States: []cosmosdb.State{cosmosdb.Unstarted, cosmosdb.Started}, ContractID: "0x123", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		msgs, err = o.ListMsgs(ctx, MsgFilter{CreatedAfter: time.Now().Add(time.Hour), Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+		msgs, err = o.ListMsgs(ctx, MsgFilter{CreatedBefore: time.Now().Add(time.Hour), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, ids[0], msgs[0].ID)
+
+		// Cancel skips Errored msgs
+		cancelled, err := o.CancelMsgs(ctx, ids)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, ids[:2], cancelled)
+		msgs, err = o.GetMsgs(ctx, ids[:2]...)
+		require.NoError(t, err)
+		for _, m := range msgs {
+			assert.Equal(t, cosmosdb.Errored, m.State)
+			require.NotNil(t, m.Reason)
+			assert.Equal(t, cosmosdb.Cancelled, *m.Reason)
+		}
+
+		// Resubmit copies Errored msgs
+		resubmitted, err := o.ResubmitMsgs(ctx, append(ids, 1<<40))
+		require.NoError(t, err)
+		require.Len(t, resubmitted, 3)
+		msgs, err = o.GetMsgs(ctx, resubmitted...)
+		require.NoError(t, err)
+		require.Len(t, msgs, 3)
+		for _, m := range msgs {
+			assert.Equal(t, cosmosdb.Unstarted, m.State)
+			assert.Nil(t, m.Reason)
+			assert.Equal(t, "hello", string(m.Raw))
+		}
+	})
+}
+
+// testStorage runs fn against each Storage implementation.
+func testStorage(t *testing.T, fn func(t *testing.T, chainID string, o Storage)) {
+	t.Run("memory", func(t *testing.T) {
+		chainID := RandomChainID()
+		fn(t, chainID, NewMemoryStorage(chainID))
+	})
+	t.Run("postgres", func(t *testing.T) {
+		db := NewDB(t)
+		chainID := RandomChainID()
+		fn(t, chainID, NewORM(chainID, db))
+	})
 }
 
 func NewDB(t *testing.T) *sqlx.DB {
@@ -0,0 +1,89 @@
+package txm
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+// reaper periodically deletes msgs in terminal states which are older than their configured retention.
+type reaper struct {
+	lggr logger.SugaredLogger
+	orm  Storage
+	cfg  config.Config
+}
+
+func newReaper(orm Storage, cfg config.Config, lggr logger.Logger) *reaper {
+	return &reaper{
+		lggr: logger.Sugared(logger.Named(lggr, "Reaper")),
+		orm:  orm,
+		cfg:  cfg,
+	}
+}
+
+// run reaps every ReaperPollPeriod until ctx is done.
+func (r *reaper) run(ctx context.Context) {
+	period := r.cfg.ReaperPollPeriod()
+	if period <= 0 {
+		r.lggr.Info("reaper disabled")
+		return
+	}
+	tick := time.After(utils.WithJitter(period))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			r.reap(ctx)
+			tick = time.After(utils.WithJitter(period))
+		}
+	}
+}
+
+// reap deletes expired msgs in each terminal state, in batches of up to ReaperBatchSize.
+func (r *reaper) reap(ctx context.Context) {
+	batchSize := r.cfg.ReaperBatchSize()
+	if batchSize < 1 {
+		r.lggr.Errorw("invalid reaper batch size", "batchSize", batchSize)
+		return
+	}
+	for _, rs := range []struct {
+		state     db.State
+		retention time.Duration
+	}{
+		{db.Confirmed, r.cfg.ConfirmedRetention()},
+		{db.Simulated, r.cfg.ConfirmedRetention()},
+		{db.Errored, r.cfg.ErroredRetention()},
+	} {
+		if rs.retention <= 0 {
+			continue // keep forever
+		}
+		cutoff := time.Now().Add(-rs.retention)
+		var total int64
+		for {
+			deleted, err := r.orm.DeleteMsgsBefore(ctx, rs.state, cutoff, batchSize)
+			if err != nil {
+				r.lggr.Errorw("unable to delete msgs", "err", err, "state", rs.state, "deleted", total)
+				return
+			}
+			total += deleted
+			if deleted < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+		if total > 0 {
+			r.lggr.Infow("deleted msgs", "state", rs.state, "count", total, "cutoff", cutoff)
+		}
+	}
+	counts, err := r.orm.CountMsgsByState(ctx)
+	if err != nil {
+		r.lggr.Warnw("unable to count msgs", "err", err)
+		return
+	}
+	r.lggr.Debugw("msg counts", "counts", counts)
+}
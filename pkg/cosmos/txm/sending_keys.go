@@ -0,0 +1,54 @@
+package txm
+
+import (
+	"slices"
+	"sync"
+)
+
+// sendingKeys tracks the keys which may send msgs on behalf of a signer, by wrapping them in an authz MsgExec.
+// Pools are only kept in memory, and are set by the relayer from its config when it starts.
+type sendingKeys struct {
+	mu    sync.RWMutex
+	pools map[string][]string
+}
+
+func newSendingKeys() *sendingKeys {
+	return &sendingKeys{pools: make(map[string][]string)}
+}
+
+// set replaces the pool of keys for signer.
+func (sk *sendingKeys) set(signer string, keys []string) {
+	pool := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k != signer && !slices.Contains(pool, k) {
+			pool = append(pool, k)
+		}
+	}
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	sk.pools[signer] = pool
+}
+
+// candidates returns the keys which may send msgs signed by signer, starting with signer itself.
+func (sk *sendingKeys) candidates(signer string) []string {
+	sk.mu.RLock()
+	defer sk.mu.RUnlock()
+	return append([]string{signer}, sk.pools[signer]...)
+}
+
+// pick returns the least busy key which may send msgs signed by signer, skipping those in busy.
+// inFlight reports how many txs a key has in flight. Returns false if every key is busy.
+func (sk *sendingKeys) pick(signer string, busy map[string]struct{}, inFlight func(string) int64) (string, bool) {
+	var best string
+	var bestInFlight int64
+	for _, k := range sk.candidates(signer) {
+		if _, ok := busy[k]; ok {
+			continue
+		}
+		n := inFlight(k)
+		if best == "" || n < bestInFlight {
+			best, bestInFlight = k, n
+		}
+	}
+	return best, best != ""
+}
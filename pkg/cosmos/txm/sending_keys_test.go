@@ -0,0 +1,42 @@
+package txm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendingKeys(t *testing.T) {
+	sk := newSendingKeys()
+	inFlight := map[string]int64{}
+	pick := func(signer string, busy ...string) (string, bool) {
+		b := map[string]struct{}{}
+		for _, k := range busy {
+			b[k] = struct{}{}
+		}
+		return sk.pick(signer, b, func(k string) int64 { return inFlight[k] })
+	}
+
+	// No pool
+	k, ok := pick("a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", k)
+	_, ok = pick("a", "a")
+	assert.False(t, ok)
+
+	sk.set("a", []string{"b", "a", "c", "b"})
+	assert.Equal(t, []string{"a", "b", "c"}, sk.candidates("a"))
+
+	// Signer is preferred
+	k, _ = pick("a")
+	assert.Equal(t, "a", k)
+	// Then the least busy key
+	inFlight["a"] = 2
+	inFlight["b"] = 1
+	k, _ = pick("a")
+	assert.Equal(t, "c", k)
+	k, _ = pick("a", "c")
+	assert.Equal(t, "b", k)
+	_, ok = pick("a", "a", "b", "c")
+	assert.False(t, ok)
+}
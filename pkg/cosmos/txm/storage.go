@@ -0,0 +1,38 @@
+package txm
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+// Storage persists the msgs of a Txm for a single chain.
+// ORM stores msgs in the cosmos_msgs table of a Postgres database, and MemoryStorage keeps them in memory
+// for standalone tools and tests.
+type Storage interface {
+	// Transaction calls fn with a Storage whose changes are all applied, or none are if fn returns an error.
+	Transaction(ctx context.Context, fn func(Storage) error) error
+
+	InsertMsg(ctx context.Context, contractID, typeURL string, msg []byte) (int64, error)
+	InsertMsgWithKey(ctx context.Context, contractID string, coalesceKey *string, typeURL string, msg []byte) (int64, error)
+	UpdateMsgsContract(ctx context.Context, contractID string, from, to db.State) error
+	CancelUnstartedMsgs(ctx context.Context, contractID string, coalesceKey *string, reason db.Reason) ([]int64, error)
+	GetMsgsState(ctx context.Context, state db.State, limit int64) (adapters.Msgs, error)
+	GetMsgs(ctx context.Context, ids ...int64) (adapters.Msgs, error)
+	UpdateMsgsErrored(ctx context.Context, ids []int64, reason db.Reason, errCode *uint32, errLog *string) error
+	UpdateMsgs(ctx context.Context, ids []int64, state db.State, txHash *string) error
+	UpdateMsgsBroadcasted(ctx context.Context, ids []int64, txHash string, timeoutHeight int64) error
+	UpdateMsgsSimulated(ctx context.Context, ids []int64, gasUsed int64) error
+	DeleteMsgsBefore(ctx context.Context, state db.State, cutoff time.Time, limit int64) (int64, error)
+	CountMsgsByState(ctx context.Context) (map[db.State]int64, error)
+	ListMsgs(ctx context.Context, f MsgFilter) (adapters.Msgs, error)
+	CancelMsgs(ctx context.Context, ids []int64) ([]int64, error)
+	ResubmitMsgs(ctx context.Context, ids []int64) ([]int64, error)
+}
+
+var (
+	_ Storage = (*ORM)(nil)
+	_ Storage = (*MemoryStorage)(nil)
+)
@@ -0,0 +1,181 @@
+package txm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+)
+
+const (
+	// txOverheadBytes is an upper bound on the size of a signed tx besides its msgs,
+	// i.e. the signer's public key and signature, the fee and the timeout height.
+	txOverheadBytes = 512
+	// msgOverheadBytes is an upper bound on the size of the field tag and length which wrap each msg in a tx.
+	msgOverheadBytes = 8
+	// consensusParamsTTL is how long the consensus params read from the chain are used for.
+	consensusParamsTTL = time.Hour
+)
+
+// txLimits are the limits on the gas limit and size of a tx. Zero means unlimited.
+type txLimits struct {
+	maxGas   int64
+	maxBytes int64
+}
+
+func (l txLimits) exceedsGas(gasLimit uint64) bool {
+	return l.maxGas > 0 && gasLimit > uint64(l.maxGas)
+}
+
+func (l txLimits) exceedsBytes(size int64) bool {
+	return l.maxBytes > 0 && size > l.maxBytes
+}
+
+// consensusParams caches the consensus params of the chain, which change rarely.
+type consensusParams struct {
+	mu        sync.Mutex
+	params    *cmttypes.ConsensusParams
+	updatedAt time.Time
+}
+
+// get returns the cached consensus params, reading them from tc if they are missing or older than consensusParamsTTL.
+// Returns the previous params along with the error if they can not be read.
+func (c *consensusParams) get(ctx context.Context, tc client.Reader) (*cmttypes.ConsensusParams, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.params != nil && time.Since(c.updatedAt) < consensusParamsTTL {
+		return c.params, nil
+	}
+	params, err := tc.ConsensusParams(ctx)
+	if err != nil {
+		return c.params, err
+	}
+	c.params, c.updatedAt = params, time.Now()
+	return params, nil
+}
+
+// txLimits returns the configured MaxGasPerTx and MaxTxBytes, defaulting to the block max gas and max bytes of the chain.
+func (txm *Txm) txLimits(ctx context.Context, tc client.Reader) txLimits {
+	l := txLimits{maxGas: txm.cfg.MaxGasPerTx(), maxBytes: txm.cfg.MaxTxBytes()}
+	if l.maxGas > 0 && l.maxBytes > 0 {
+		return l
+	}
+	params, err := txm.consensusParams.get(ctx, tc)
+	if err != nil {
+		txm.lggr.Warnw("unable to get consensus params", "err", err)
+	}
+	if params == nil {
+		return l
+	}
+	// Negative block max gas means unlimited
+	if l.maxGas == 0 && params.Block.MaxGas > 0 {
+		l.maxGas = params.Block.MaxGas
+	}
+	if l.maxBytes == 0 && params.Block.MaxBytes > 0 {
+		l.maxBytes = params.Block.MaxBytes
+	}
+	return l
+}
+
+// msgBytes returns an upper bound on the bytes msg adds to a tx.
+func msgBytes(msg sdk.Msg) int64 {
+	a, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return 0
+	}
+	return int64(a.Size()) + msgOverheadBytes
+}
+
+// txBytes returns an upper bound on the size of a signed tx of msgs.
+func txBytes(msgs client.SimMsgs) int64 {
+	size := int64(txOverheadBytes)
+	for _, m := range msgs {
+		size += msgBytes(m.Msg)
+	}
+	return size
+}
+
+// maxConcurrentSims is the maximum number of concurrent simulations made by simulateEach.
+const maxConcurrentSims = 10
+
+// simulateEach sets the GasUsed of each of msgs which does not have it yet, by simulating it on its own.
+func (txm *Txm) simulateEach(ctx context.Context, tc client.ReaderWriter, msgs client.SimMsgs, sequence uint64) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSims)
+	for i := range msgs {
+		if msgs[i].GasUsed > 0 {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(m *client.SimMsg) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s, err := tc.SimulateUnsigned(ctx, []sdk.Msg{m.Msg}, sequence)
+			if err != nil {
+				txm.lggr.Debugw("unable to simulate msg on its own", "err", err, "id", m.ID)
+				return
+			}
+			m.GasUsed = s.GasInfo.GasUsed
+		}(&msgs[i])
+	}
+	wg.Wait()
+}
+
+// splitTx splits msgs, whose tx exceeds limits, into smaller txs. The gas used by each msg is only simulated
+// on its own at this point, since most batches fit in a single tx. Msgs which exceed the limits on their own
+// are marked as errored.
+func (txm *Txm) splitTx(ctx context.Context, tc client.ReaderWriter, sender sdk.AccAddress, msgs client.SimMsgs, limits txLimits, sequence uint64) ([]client.SimMsgs, error) {
+	if len(msgs) == 1 {
+		return nil, txm.markOversized(ctx, msgs)
+	}
+	txm.simulateEach(ctx, tc, msgs, sequence)
+	txs, oversized := packTxs(msgs, limits, txm.cfg.GasLimitMultiplier())
+	if err := txm.markOversized(ctx, oversized); err != nil {
+		return nil, err
+	}
+	if len(txs) == 1 && len(txs[0]) > 1 {
+		// The estimates were too low, e.g. for msgs which could not be simulated on their own, so halve the tx
+		half := len(txs[0]) / 2
+		txs = []client.SimMsgs{txs[0][:half], txs[0][half:]}
+	}
+	txm.lggr.Infow("splitting tx to stay within the tx limits", "from", sender, "msgs", len(msgs), "txs", len(txs),
+		"maxGas", limits.maxGas, "maxBytes", limits.maxBytes)
+	return txs, nil
+}
+
+// packTxs splits msgs into txs in order, so that the estimated gas limit and size of each tx stays within limits,
+// using the gas used by each msg on its own multiplied by gasLimitMultiplier. Msgs which exceed the limits on
+// their own are returned as oversized.
+// Since the gas used by each msg on its own includes the gas used by the tx, the estimates are conservative.
+func packTxs(msgs client.SimMsgs, limits txLimits, gasLimitMultiplier float64) (txs []client.SimMsgs, oversized client.SimMsgs) {
+	var tx client.SimMsgs
+	var gas uint64
+	size := int64(txOverheadBytes)
+	for _, m := range msgs {
+		mGas := uint64(float64(m.GasUsed) * gasLimitMultiplier)
+		mSize := msgBytes(m.Msg)
+		if limits.exceedsGas(mGas) || limits.exceedsBytes(txOverheadBytes+mSize) {
+			oversized = append(oversized, m)
+			continue
+		}
+		if len(tx) > 0 && (limits.exceedsGas(gas+mGas) || limits.exceedsBytes(size+mSize)) {
+			txs = append(txs, tx)
+			tx, gas, size = nil, 0, txOverheadBytes
+		}
+		tx = append(tx, m)
+		gas += mGas
+		size += mSize
+	}
+	if len(tx) > 0 {
+		txs = append(txs, tx)
+	}
+	return txs, oversized
+}
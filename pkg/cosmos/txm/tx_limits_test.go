@@ -0,0 +1,241 @@
+package txm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	tmservicetypes "github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client/mocks"
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
+	cosmosdb "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/db"
+)
+
+func TestPackTxs(t *testing.T) {
+	ks := newKeystore(2)
+	from, to := ks.address(0), ks.address(1)
+	simMsg := func(id int64, gasUsed uint64, size int) client.SimMsg {
+		return client.SimMsg{ID: id, Msg: generateExecuteMsg(make([]byte, size), from, to), GasUsed: gasUsed}
+	}
+	msgs := client.SimMsgs{simMsg(1, 100, 10), simMsg(2, 200, 10), simMsg(3, 300, 1000), simMsg(4, 0, 10)}
+	msgSize := msgBytes(msgs[0].Msg)
+
+	ids := func(txs []client.SimMsgs) (ids [][]int64) {
+		for _, tx := range txs {
+			ids = append(ids, tx.GetSimMsgsIDs())
+		}
+		return
+	}
+	for _, tt := range []struct {
+		name      string
+		limits    txLimits
+		txs       [][]int64
+		oversized []int64
+	}{
+		{"unlimited", txLimits{}, [][]int64{{1, 2, 3, 4}}, nil},
+		{"gas", txLimits{maxGas: 450}, [][]int64{{1, 2}, {3, 4}}, nil},
+		{"gas oversized", txLimits{maxGas: 400}, [][]int64{{1}, {2, 4}}, []int64{3}},
+		{"bytes", txLimits{maxBytes: txOverheadBytes + 2*msgSize}, [][]int64{{1, 2}, {4}}, []int64{3}},
+		{"gas and bytes", txLimits{maxGas: 300, maxBytes: txOverheadBytes + 2*msgSize}, [][]int64{{1}, {2, 4}}, []int64{3}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// Gas limits are 1.5x the gas used
+			txs, oversized := packTxs(msgs, tt.limits, 1.5)
+			assert.Equal(t, tt.txs, ids(txs))
+			if tt.oversized == nil {
+				assert.Empty(t, oversized)
+			} else {
+				assert.Equal(t, tt.oversized, oversized.GetSimMsgsIDs())
+			}
+		})
+	}
+}
+
+func TestTxm_splitBatch(t *testing.T) {
+	lggr := logger.Test(t)
+	ks := newKeystore(2)
+	sender, contract := ks.address(0), ks.address(1)
+
+	const gasPerMsg = 400_000
+	const large = `"large"`
+	// simulate uses gasPerMsg for each msg, except for large msgs
+	simulate := func(_ context.Context, msgs []cosmostypes.Msg, _ uint64) *txtypes.SimulateResponse {
+		var gas uint64
+		for _, m := range msgs {
+			if string(m.(*wasmtypes.MsgExecuteContract).Msg) == large {
+				gas += 2_000_000
+			} else {
+				gas += gasPerMsg
+			}
+		}
+		return &txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{GasUsed: gas}}
+	}
+	isSingle := mock.MatchedBy(func(msgs []cosmostypes.Msg) bool { return len(msgs) == 1 })
+	newTxm := func(t *testing.T, tc *mocks.ReaderWriter, chain config.Chain, msgs ...string) (*Txm, []int64) {
+		if chain.ShadowMode == nil {
+			shadow := true
+			chain.ShadowMode = &shadow
+		}
+		cfg := &config.TOMLConfig{Chain: chain}
+		cfg.SetDefaults()
+		gpe := client.NewComposedGasPriceEstimator([]client.GasPricesEstimator{
+			client.NewFixedGasPriceEstimator(map[string]cosmostypes.DecCoin{
+				cfg.GasToken(): cosmostypes.NewDecCoinFromDec(cfg.GasToken(), cosmostypes.MustNewDecFromStr("0.01")),
+			}, logger.Sugared(lggr)),
+		}, nil, lggr)
+		chainID := RandomChainID()
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := NewTxmWithStorage(NewMemoryStorage(chainID), tcFn, gpe, chainID, cfg, ks, lggr)
+
+		var ids []int64
+		for _, msg := range msgs {
+			id, err := txm.Enqueue(tests.Context(t), contract.String(), generateExecuteMsg([]byte(msg), sender, contract), adapters.WithAppend())
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		tc.On("Account", mock.Anything, sender).Return(uint64(0), uint64(0), nil)
+		tc.On("BatchSimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, msgs client.SimMsgs, _ uint64) *client.BatchSimResults {
+			return &client.BatchSimResults{Succeeded: msgs}
+		}, nil)
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil)
+		// Each sequence gets its own signed tx, and so its own hash
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(func(_ []cosmostypes.Msg, _, sequence, _ uint64, _ float64, _ cosmostypes.DecCoin, _ cosmostypes.AccAddress, _ cryptotypes.PrivKey, _ uint64) []byte {
+				return []byte{byte(sequence + 1)}
+			}, nil)
+		return txm, ids
+	}
+	// states returns the state of each msg
+	states := func(t *testing.T, txm *Txm, ids []int64) []cosmosdb.State {
+		ms, err := txm.GetMsgs(tests.Context(t), ids...)
+		require.NoError(t, err)
+		states := make([]cosmosdb.State, len(ms))
+		for i, m := range ms {
+			states[i] = m.State
+		}
+		return states
+	}
+	// simulatedGas returns the gas of the simulated tx of each msg
+	simulatedGas := func(t *testing.T, txm *Txm, ids []int64) []int64 {
+		ms, err := txm.GetMsgs(tests.Context(t), ids...)
+		require.NoError(t, err)
+		gas := make([]int64, len(ms))
+		for i, m := range ms {
+			if m.SimulatedGas != nil {
+				gas[i] = *m.SimulatedGas
+			}
+		}
+		return gas
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		txm, ids := newTxm(t, tc, config.Chain{}, `{}`, `{}`, `{}`, `{}`)
+		// Only the whole tx is simulated
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(simulate, nil).Once()
+		sendMsgBatchAndWait(ctx, txm)
+
+		assert.Equal(t, []int64{4 * gasPerMsg, 4 * gasPerMsg, 4 * gasPerMsg, 4 * gasPerMsg}, simulatedGas(t, txm, ids))
+	})
+
+	t.Run("consensus params", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newPollingReaderWriter(t)
+		// Two msgs fit in a block, with the 1.5x gas limit multiplier. The last msg is too large for a block.
+		txm, ids := newTxm(t, tc, config.Chain{}, `{}`, `{}`, `{}`, large)
+		tc.On("ConsensusParams", mock.Anything).Return(&cmttypes.ConsensusParams{Block: cmttypes.BlockParams{MaxGas: 1_500_000, MaxBytes: 1_000_000}}, nil).Once()
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(simulate, nil)
+		sendMsgBatchAndWait(ctx, txm)
+
+		assert.Equal(t, []int64{2 * gasPerMsg, 2 * gasPerMsg, gasPerMsg, 0}, simulatedGas(t, txm, ids))
+		ms, err := txm.GetMsgs(ctx, ids[3])
+		require.NoError(t, err)
+		assert.Equal(t, cosmosdb.Errored, ms[0].State)
+		assert.Equal(t, ptr(cosmosdb.Oversized), ms[0].Reason)
+	})
+
+	t.Run("max tx bytes", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		// Two msgs fit in a tx
+		maxBytes := txOverheadBytes + 2*msgBytes(generateExecuteMsg([]byte(`{}`), sender, contract))
+		txm, ids := newTxm(t, tc, config.Chain{MaxTxBytes: &maxBytes}, `{}`, `{}`, `{}`, `{}`)
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(simulate, nil)
+		sendMsgBatchAndWait(ctx, txm)
+
+		assert.Equal(t, []int64{2 * gasPerMsg, 2 * gasPerMsg, 2 * gasPerMsg, 2 * gasPerMsg}, simulatedGas(t, txm, ids))
+	})
+
+	t.Run("split after simulation", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		maxGas := int64(1_500_000)
+		txm, ids := newTxm(t, tc, config.Chain{MaxGasPerTx: &maxGas}, `{}`, `{}`, `{}`, `{}`)
+		// No msg can be simulated on its own, so the tx is halved until it fits
+		tc.On("SimulateUnsigned", mock.Anything, isSingle, mock.Anything).Return(nil, errors.New("depends on an earlier msg"))
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(simulate, nil)
+		sendMsgBatchAndWait(ctx, txm)
+
+		assert.Equal(t, []int64{2 * gasPerMsg, 2 * gasPerMsg, 2 * gasPerMsg, 2 * gasPerMsg}, simulatedGas(t, txm, ids))
+	})
+	t.Run("broadcast", func(t *testing.T) {
+		// The hashes of the txs signed with sequences 0 and 1
+		txHashes := []string{
+			"4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A",
+			"DBC1B4C900FFE48D575B5DA5C638040125F65DB0FE3E24494B76EA986457D986",
+		}
+		newBroadcastTxm := func(t *testing.T, tc *mocks.ReaderWriter, maxInFlight int64) (*Txm, []int64) {
+			shadow := false
+			maxGas := int64(1_500_000)
+			txm, ids := newTxm(t, tc, config.Chain{ShadowMode: &shadow, MaxGasPerTx: &maxGas, MaxInFlightTxs: &maxInFlight}, `{}`, `{}`, `{}`, `{}`)
+			tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(simulate, nil)
+			for i, txHash := range txHashes {
+				tc.On("Broadcast", mock.Anything, []byte{byte(i + 1)}, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: &cosmostypes.TxResponse{TxHash: txHash}}, nil).Once()
+				tc.On("Tx", mock.Anything, txHash).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: &cosmostypes.TxResponse{TxHash: txHash}}, nil).Once()
+			}
+			return txm, ids
+		}
+
+		t.Run("pipelined", func(t *testing.T) {
+			ctx := tests.Context(t)
+			tc := newReaderWriter(t)
+			txm, ids := newBroadcastTxm(t, tc, 2)
+			// Both txs are broadcast at once, with consecutive sequences
+			sendMsgBatchAndWait(ctx, txm)
+
+			assert.Equal(t, []cosmosdb.State{cosmosdb.Confirmed, cosmosdb.Confirmed, cosmosdb.Confirmed, cosmosdb.Confirmed}, states(t, txm, ids))
+			for _, sn := range []uint64{0, 1} {
+				tc.AssertCalled(t, "CreateAndSign", mock.Anything, uint64(0), sn, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+
+		t.Run("one in flight", func(t *testing.T) {
+			ctx := tests.Context(t)
+			tc := newReaderWriter(t)
+			txm, ids := newBroadcastTxm(t, tc, 1)
+			// The second tx waits for the first to be confirmed
+			sendMsgBatchAndWait(ctx, txm)
+			assert.Equal(t, []cosmosdb.State{cosmosdb.Confirmed, cosmosdb.Confirmed, cosmosdb.Started, cosmosdb.Started}, states(t, txm, ids))
+
+			sendMsgBatchAndWait(ctx, txm)
+			assert.Equal(t, []cosmosdb.State{cosmosdb.Confirmed, cosmosdb.Confirmed, cosmosdb.Confirmed, cosmosdb.Confirmed}, states(t, txm, ids))
+		})
+	})
+}
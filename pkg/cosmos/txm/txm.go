@@ -8,16 +8,17 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 
-	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
-	"github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/fee"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
@@ -39,21 +40,49 @@ var (
 type Txm struct {
 	services.StateMachine
 	newMsgs         chan struct{}
-	orm             *ORM
+	orm             Storage
 	lggr            logger.SugaredLogger
 	tc              func() (client.ReaderWriter, error)
 	keystoreAdapter *keystoreAdapter
 	stop, done      chan struct{}
 	cfg             config.Config
-	gpe             client.ComposedGasPriceEstimator
+	gpe             *client.ComposedGasPriceEstimator
+
+	nonces  *nonceManager
+	keys    *sendingKeys
+	events  *msgEvents
+	reaper  *reaper
+	grants  *feeGrantChecker
+	metrics txmMetrics
+	// consensusParams are the default tx limits, unless MaxGasPerTx and MaxTxBytes are configured.
+	consensusParams consensusParams
+
+	// workers tracks senders with a batch in progress, each sender has at most one worker at a time.
+	workersMu sync.Mutex
+	workers   map[string]struct{}
+	wg        sync.WaitGroup
 }
 
 // NewTxm creates a txm. Uses simulation so should only be used to send txes to trusted contracts i.e. OCR.
-func NewTxm(ds sqlutil.DataSource, tc func() (client.ReaderWriter, error), gpe client.ComposedGasPriceEstimator, chainID string, cfg config.Config, ks loop.Keystore, lggr logger.Logger) *Txm {
+// Returns an error if the cosmos migrations in package db have not been applied to ds, unless ds has a
+// cosmos_msgs table from before the migrations moved here, in which case it only warns.
+func NewTxm(ctx context.Context, ds sqlutil.DataSource, tc func() (client.ReaderWriter, error), gpe *client.ComposedGasPriceEstimator, chainID string, cfg config.Config, ks loop.Keystore, lggr logger.Logger) (*Txm, error) {
+	if err := db.CheckSchemaVersion(ctx, ds); errors.Is(err, db.ErrUnversionedSchema) {
+		logger.Sugared(lggr).Warnw("Run the cosmos migrations to baseline the existing cosmos_msgs table, newer columns may be missing until then",
+			"err", err, "table", db.MigrationsTable, "version", db.SchemaVersion)
+	} else if err != nil {
+		return nil, err
+	}
+	return NewTxmWithStorage(NewORM(chainID, ds), tc, gpe, chainID, cfg, ks, lggr), nil
+}
+
+// NewTxmWithStorage creates a txm like NewTxm, which stores msgs in orm instead of the cosmos_msgs table,
+// e.g. a MemoryStorage for standalone tools and tests.
+func NewTxmWithStorage(orm Storage, tc func() (client.ReaderWriter, error), gpe *client.ComposedGasPriceEstimator, chainID string, cfg config.Config, ks loop.Keystore, lggr logger.Logger) *Txm {
 	keystoreAdapter := newKeystoreAdapter(ks, cfg.Bech32Prefix())
 	return &Txm{
 		newMsgs:         make(chan struct{}, 1), // buffered to hold one pending request while unblocking callers
-		orm:             NewORM(chainID, ds),
+		orm:             orm,
 		lggr:            logger.Sugared(lggr).Named("Txm"),
 		tc:              tc,
 		keystoreAdapter: keystoreAdapter,
@@ -61,6 +90,13 @@ func NewTxm(ds sqlutil.DataSource, tc func() (client.ReaderWriter, error), gpe c
 		done:            make(chan struct{}),
 		cfg:             cfg,
 		gpe:             gpe,
+		nonces:          newNonceManager(cfg.MaxInFlightTxs(), lggr),
+		keys:            newSendingKeys(),
+		events:          newMsgEvents(logger.Sugared(lggr).Named("Txm")),
+		reaper:          newReaper(orm, cfg, lggr),
+		grants:          newFeeGrantChecker(tc, keystoreAdapter, cfg, lggr),
+		metrics:         txmMetrics{chainID: chainID},
+		workers:         make(map[string]struct{}),
 	}
 }
 
@@ -74,7 +110,14 @@ func (txm *Txm) Start(context.Context) error {
 
 func (txm *Txm) Name() string { return txm.lggr.Name() }
 
-func (txm *Txm) HealthReport() map[string]error { return map[string]error{txm.Name(): txm.Healthy()} }
+func (txm *Txm) HealthReport() map[string]error {
+	report := map[string]error{txm.Name(): txm.Healthy()}
+	services.CopyHealth(report, txm.gpe.HealthReport())
+	if txm.cfg.FeeGranter() != "" {
+		report[txm.grants.name()] = txm.grants.healthy()
+	}
+	return report
+}
 
 func (txm *Txm) confirmAnyUnconfirmed(ctx context.Context) {
 	// Confirm any broadcasted but not confirmed txes.
@@ -99,8 +142,16 @@ func (txm *Txm) confirmAnyUnconfirmed(ctx context.Context) {
 			msgsByTxHash[*msg.TxHash] = append(msgsByTxHash[*msg.TxHash], msg)
 		}
 		for txHash, msgs := range msgsByTxHash {
-			maxPolls, pollPeriod := txm.confirmPollConfig()
-			err := txm.confirmTx(ctx, tc, txHash, msgs.GetIDs(), maxPolls, pollPeriod)
+			timeoutHeight, err := txm.storedTimeoutHeight(ctx, tc, msgs)
+			if err != nil {
+				txm.lggr.Errorw("unable to get timeout height of broadcasted but unconfirmed txes", "err", err, "txhash", txHash)
+				return
+			}
+			var sender string
+			if _, s, err2 := unmarshalMsg(msgs[0].Type, msgs[0].Raw); err2 == nil {
+				sender = s
+			}
+			err = txm.confirmTx(ctx, tc, sender, txHash, msgs.GetIDs(), timeoutHeight, txm.cfg.ConfirmPollPeriod())
 			if err != nil {
 				txm.lggr.Errorw("unable to confirm broadcasted but unconfirmed txes", "err", err, "txhash", txHash)
 				if ctx.Err() != nil {
@@ -111,13 +162,41 @@ func (txm *Txm) confirmAnyUnconfirmed(ctx context.Context) {
 	}
 }
 
+// storedTimeoutHeight returns the timeout height stored with msgs. Msgs broadcast before timeout heights were
+// stored are given BlocksUntilTxTimeout from the latest height, which is at least as late as the real one.
+func (txm *Txm) storedTimeoutHeight(ctx context.Context, tc client.Reader, msgs adapters.Msgs) (int64, error) {
+	for _, m := range msgs {
+		if m.TimeoutHeight != nil {
+			return *m.TimeoutHeight, nil
+		}
+	}
+	lb, err := tc.LatestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return lb.SdkBlock.Header.Height + txm.cfg.BlocksUntilTxTimeout(), nil
+}
+
 func (txm *Txm) run() {
 	defer close(txm.done)
+	defer txm.wg.Wait()
 	ctx, cancel := utils.ContextFromChan(txm.stop)
 	defer cancel()
+	txm.wg.Add(1)
+	go func() {
+		defer txm.wg.Done()
+		txm.reaper.run(ctx)
+	}()
+	txm.wg.Add(1)
+	go func() {
+		defer txm.wg.Done()
+		txm.grants.run(ctx)
+	}()
 	txm.confirmAnyUnconfirmed(ctx)
+	txm.updateMsgCounts(ctx)
 	// Jitter in case we have multiple cosmos chains each with their own client.
 	tick := time.After(utils.WithJitter(txm.cfg.BlockRate()))
+	countsTick := time.After(utils.WithJitter(msgCountsPeriod))
 	for {
 		select {
 		case <-txm.newMsgs:
@@ -125,35 +204,43 @@ func (txm *Txm) run() {
 		case <-tick:
 			txm.sendMsgBatch(ctx)
 			tick = time.After(utils.WithJitter(txm.cfg.BlockRate()))
+		case <-countsTick:
+			txm.updateMsgCounts(ctx)
+			countsTick = time.After(utils.WithJitter(msgCountsPeriod))
 		case <-txm.stop:
 			return
 		}
 	}
 }
 
-var (
-	typeMsgSend            = sdk.MsgTypeURL(&types.MsgSend{})
-	typeMsgExecuteContract = sdk.MsgTypeURL(&wasmtypes.MsgExecuteContract{})
-)
+// msgCountsPeriod is how often the msg counts are reported. Counting scans every msg of the chain,
+// including terminal ones kept for their retention, so it is done much less often than sending.
+const msgCountsPeriod = time.Minute
+
+// updateMsgCounts reports the number of msgs in each state.
+func (txm *Txm) updateMsgCounts(ctx context.Context) {
+	counts, err := txm.orm.CountMsgsByState(ctx)
+	if err != nil {
+		txm.lggr.Warnw("unable to count msgs", "err", err)
+		return
+	}
+	txm.metrics.setMsgCounts(counts)
+}
 
 func unmarshalMsg(msgType string, raw []byte) (sdk.Msg, string, error) {
-	switch msgType {
-	case typeMsgSend:
-		var ms types.MsgSend
-		err := ms.Unmarshal(raw)
-		if err != nil {
-			return nil, "", err
-		}
-		return &ms, ms.FromAddress, nil
-	case typeMsgExecuteContract:
-		var ms wasmtypes.MsgExecuteContract
-		err := ms.Unmarshal(raw)
-		if err != nil {
-			return nil, "", err
-		}
-		return &ms, ms.Sender, nil
+	t, ok := adapters.GetMsgType(msgType)
+	if !ok {
+		return nil, "", fmt.Errorf("unrecognized message type: %s", msgType)
+	}
+	msg, err := t.Unmarshal(raw)
+	if err != nil {
+		return nil, "", err
 	}
-	return nil, "", fmt.Errorf("unrecognized message type: %s", msgType)
+	sender, err := t.Sender(msg)
+	if err != nil {
+		return nil, "", err
+	}
+	return msg, sender, nil
 }
 
 type msgValidator struct {
@@ -183,15 +270,36 @@ func (e *msgValidator) sortValid() {
 }
 
 func (txm *Txm) sendMsgBatch(ctx context.Context) {
+	// Snapshot the busy senders before reading any msgs. Their workers own their Started msgs,
+	// and a worker only becomes idle after it has finished updating them.
+	busy := txm.busySenders()
+	isBusy := func(m adapters.Msg) bool {
+		_, signer, err := unmarshalMsg(m.Type, m.Raw)
+		if err != nil {
+			return false // logged below
+		}
+		_, ok := txm.keys.pick(signer, busy, txm.nonces.inFlight)
+		return !ok
+	}
 	msgs := msgValidator{cutoff: time.Now().Add(-txm.cfg.TxMsgTimeout())}
-	err := txm.orm.Transaction(ctx, func(orm *ORM) error {
+	var newlyStarted []int64
+	err := txm.orm.Transaction(ctx, func(orm Storage) error {
 		// There may be leftover Started messages after a crash or failed send attempt.
 		started, err := orm.GetMsgsState(ctx, db.Started, txm.cfg.MaxMsgsPerBatch())
 		if err != nil {
 			txm.lggr.Errorw("unable to read unstarted msgs", "err", err)
 			return err
 		}
-		if limit := txm.cfg.MaxMsgsPerBatch() - int64(len(started)); limit > 0 {
+		var idle adapters.Msgs
+		for _, msg := range started {
+			if isBusy(msg) {
+				// Being processed by the sender's worker
+				continue
+			}
+			idle = append(idle, msg)
+		}
+		// Busy senders' Started msgs are not part of this batch, so they must not use up its budget.
+		if limit := txm.cfg.MaxMsgsPerBatch() - int64(len(idle)); limit > 0 {
 			// Use the remaining batch budget for Unstarted
 			unstarted, err := orm.GetMsgsState(ctx, db.Unstarted, limit) //nolint
 			if err != nil {
@@ -199,21 +307,26 @@ func (txm *Txm) sendMsgBatch(ctx context.Context) {
 				return err
 			}
 			for _, msg := range unstarted {
+				if isBusy(msg) {
+					// Leave unstarted until the sender's worker is done
+					continue
+				}
 				msgs.add(msg)
 			}
 			// Update valid, Unstarted messages to Started
-			err = orm.UpdateMsgs(ctx, msgs.valid.GetIDs(), db.Started, nil)
+			newlyStarted = msgs.valid.GetIDs()
+			err = orm.UpdateMsgs(ctx, newlyStarted, db.Started, nil)
 			if err != nil {
 				// Assume transient db error retry
 				txm.lggr.Errorw("unable to mark unstarted txes as started", "err", err)
 				return err
 			}
 		}
-		for _, msg := range started {
+		for _, msg := range idle {
 			msgs.add(msg)
 		}
 		// Update expired messages (Unstarted or Started) to Errored
-		err = orm.UpdateMsgs(ctx, msgs.expired.GetIDs(), db.Errored, nil)
+		err = orm.UpdateMsgsErrored(ctx, msgs.expired.GetIDs(), db.Expired, nil, nil)
 		if err != nil {
 			// Assume transient db error retry
 			txm.lggr.Errorw("unable to mark expired txes as errored", "err", err)
@@ -224,62 +337,133 @@ func (txm *Txm) sendMsgBatch(ctx context.Context) {
 	if err != nil {
 		return
 	}
+	txm.events.publish(newlyStarted, adapters.MsgEvent{State: db.Started})
+	txm.events.publish(msgs.expired.GetIDs(), adapters.MsgEvent{State: db.Errored, Reason: ptr(db.Expired)})
 	if len(msgs.valid) == 0 {
 		return
 	}
 	msgs.sortValid()
 	txm.lggr.Debugw("building a batch", "not expired", msgs.valid, "marked expired", msgs.expired)
 	var msgsByFrom = make(map[string]adapters.Msgs)
+	// All msgs of a signer in this batch are sent by the same key
+	senderBySigner := make(map[string]string)
 	for _, m := range msgs.valid {
-		msg, sender, err2 := unmarshalMsg(m.Type, m.Raw)
+		msg, signer, err2 := unmarshalMsg(m.Type, m.Raw)
 		if err2 != nil {
 			// Should be impossible given the check in Enqueue
 			txm.lggr.Criticalw("Failed to unmarshal msg, skipping", "err", err2, "msg", m)
 			continue
 		}
-		m.DecodedMsg = msg
-		_, err2 = sdk.AccAddressFromBech32(sender)
+		sender, ok := senderBySigner[signer]
+		if !ok {
+			sender, ok = txm.keys.pick(signer, busy, txm.nonces.inFlight)
+			if !ok {
+				// Should be impossible given the busy check above
+				continue
+			}
+			senderBySigner[signer] = sender
+		}
+		senderAddr, err2 := sdk.AccAddressFromBech32(sender)
 		if err2 != nil {
 			// Should never happen, we parse sender on Enqueue
 			txm.lggr.Criticalw("Unable to parse sender", "err", err2, "sender", sender)
 			continue
 		}
+		if sender != signer {
+			if exec, ok := msg.(*authz.MsgExec); ok {
+				// Already sent on behalf of a granter, so sender replaces signer as the grantee rather than
+				// nesting MsgExecs. The granter must have granted sender authorization.
+				msg = &authz.MsgExec{Grantee: sender, Msgs: exec.Msgs}
+			} else {
+				// Send on behalf of signer, which must have granted sender authorization
+				exec := authz.NewMsgExec(senderAddr, []sdk.Msg{msg})
+				msg = &exec
+			}
+		}
+		m.DecodedMsg = msg
 		msgsByFrom[sender] = append(msgsByFrom[sender], m)
 	}
 
 	txm.lggr.Debugw("msgsByFrom", "msgsByFrom", msgsByFrom)
 	gasPrice, err := txm.GasPrice()
 	if err != nil {
-		// Should be impossible
-		txm.lggr.Criticalw("Failed to get gas price", "err", err)
+		// Leave the msgs Started, they are retried with the next batch
+		txm.lggr.Errorw("Failed to get gas price", "err", err)
 		return
 	}
 	for s, msgs := range msgsByFrom {
 		sender, _ := sdk.AccAddressFromBech32(s) // Already checked validity above
+		txm.startWorker(ctx, gasPrice, sender, msgs)
+	}
+}
+
+// busySenders returns a snapshot of the senders which currently have a worker,
+// or which have the max number of txs in flight.
+func (txm *Txm) busySenders() map[string]struct{} {
+	txm.workersMu.Lock()
+	busy := make(map[string]struct{}, len(txm.workers))
+	for s := range txm.workers {
+		busy[s] = struct{}{}
+	}
+	txm.workersMu.Unlock()
+	for _, s := range txm.nonces.full() {
+		busy[s] = struct{}{}
+	}
+	return busy
+}
+
+// startWorker sends a batch of msgs from sender in the background, so that
+// a slow confirmation for one sender does not hold up the others.
+// Callers must ensure that sender does not already have a worker.
+func (txm *Txm) startWorker(ctx context.Context, gasPrice sdk.DecCoin, sender sdk.AccAddress, msgs adapters.Msgs) {
+	from := sender.String()
+	txm.workersMu.Lock()
+	txm.workers[from] = struct{}{}
+	txm.workersMu.Unlock()
+
+	txm.wg.Add(1)
+	go func() {
+		defer txm.wg.Done()
 		err := txm.sendMsgBatchFromAddress(ctx, gasPrice, sender, msgs)
+
+		txm.workersMu.Lock()
+		delete(txm.workers, from)
+		txm.workersMu.Unlock()
+
 		if err != nil {
-			txm.lggr.Errorw("Could not send message batch", "err", err, "from", sender.String())
-			continue
-		}
-		if ctx.Err() != nil {
+			txm.lggr.Errorw("Could not send message batch", "err", err, "from", from)
+			// Retry on the next poll
 			return
 		}
-	}
+		// Pick up any msgs which were queued for this sender in the meantime
+		txm.triggerNewMsg()
+	}()
 }
 
-func (txm *Txm) sendMsgBatchFromAddress(ctx context.Context, gasPrice sdk.DecCoin, sender sdk.AccAddress, msgs adapters.Msgs) error {
+// sendMsgBatchFromAddress simulates, signs and broadcasts a batch of msgs from sender. The batch is split into
+// several txs if needed to stay within the gas and byte limits of a tx, each with its own sequence. The txs are
+// confirmed in the background, so that the next batch can be sent while they are in flight.
+func (txm *Txm) sendMsgBatchFromAddress(ctx context.Context, gasPrice sdk.DecCoin, sender sdk.AccAddress, msgs adapters.Msgs) (err error) {
 	tc, err := txm.tc()
 	if err != nil {
 		txm.lggr.Criticalw("unable to get client", "err", err)
 		return err
 	}
-	an, sn, err := tc.Account(ctx, sender)
+	an, sn, err := txm.nonces.reserve(ctx, tc, sender)
 	if err != nil {
-		txm.lggr.Warnw("unable to read account", "err", err, "from", sender.String())
+		txm.lggr.Warnw("unable to get sequence", "err", err, "from", sender.String())
 		// If we can't read the account, assume transient api issues and leave msgs unstarted
 		// to retry on next poll.
 		return err
 	}
+	reserved := true
+	defer func() {
+		if reserved {
+			// The sequence was not used, so it can be handed out again.
+			txm.nonces.release(sender, sn, false)
+			txm.nonces.resync(sender, err)
+		}
+	}()
 
 	txm.lggr.Debugw("simulating batch", "from", sender, "msgs", msgs, "seqnum", sn)
 	simResults, err := tc.BatchSimulateUnsigned(ctx, msgs.GetSimMsgs(), sn)
@@ -289,10 +473,12 @@ func (txm *Txm) sendMsgBatchFromAddress(ctx context.Context, gasPrice sdk.DecCoi
 		// Note one rare scenario in which this can happen: the cosmos node misbehaves
 		// in that it confirms a txhash is present but still gives an old seq num.
 		// This is benign as the next retry will succeeds.
+		// Also expected when simulating against a node which has not seen our in flight txs yet.
 		return err
 	}
 	txm.lggr.Debugw("simulation results", "from", sender, "succeeded", simResults.Succeeded, "failed", simResults.Failed)
-	err = txm.orm.UpdateMsgs(ctx, simResults.Failed.GetSimMsgsIDs(), db.Errored, nil)
+	txm.metrics.observeSimulation(simResults)
+	err = txm.markSimulationFailed(ctx, simResults.Failed)
 	if err != nil {
 		txm.lggr.Errorw("unable to mark failed sim txes as errored", "err", err, "from", sender.String())
 		// If we can't mark them as failed retry on next poll. Presumably same ones will fail.
@@ -304,53 +490,212 @@ func (txm *Txm) sendMsgBatchFromAddress(ctx context.Context, gasPrice sdk.DecCoi
 		txm.lggr.Warnw("all sim msgs errored, not sending tx", "from", sender.String())
 		return errors.New("all sim msgs errored")
 	}
-	// Get the gas limit for the successful batch
-	s, err := tc.SimulateUnsigned(ctx, simResults.Succeeded.GetMsgs(), sn)
-	if err != nil {
-		// In the OCR context this should only happen upon stale report
-		txm.lggr.Warnw("unexpected failure after successful simulation", "err", err)
+
+	limits := txm.txLimits(ctx, tc)
+	txs := []client.SimMsgs{simResults.Succeeded}
+	for len(txs) > 0 {
+		txMsgs := txs[0]
+		txs = txs[1:]
+		if !reserved {
+			if an, sn, err = txm.nonces.reserve(ctx, tc, sender); err != nil {
+				// The remaining msgs are left started, and sent with the next batch.
+				if errors.Is(err, errTooManyInFlight) {
+					// Expected unless MaxInFlightTxs > 1, the next batch is sent once a tx is confirmed.
+					txm.lggr.Debugw("max txs in flight, sending the rest of the batch later", "from", sender.String(), "txs", len(txs)+1)
+					return nil
+				}
+				txm.lggr.Warnw("unable to get sequence for the rest of the batch", "err", err, "from", sender.String())
+				return err
+			}
+			reserved = true
+		}
+
+		// Get the gas limit for the tx
+		s, err2 := tc.SimulateUnsigned(ctx, txMsgs.GetMsgs(), sn)
+		if err2 != nil {
+			// In the OCR context this should only happen upon stale report
+			txm.lggr.Warnw("unexpected failure after successful simulation", "err", err2)
+			return err2
+		}
+		gasLimit := s.GasInfo.GasUsed
+		if limits.exceedsGas(uint64(float64(gasLimit)*txm.cfg.GasLimitMultiplier())) || limits.exceedsBytes(txBytes(txMsgs)) {
+			split, err2 := txm.splitTx(ctx, tc, sender, txMsgs, limits, sn)
+			if err2 != nil {
+				return err2
+			}
+			txs = append(split, txs...)
+			continue
+		}
+
+		if txm.cfg.ShadowMode() {
+			// The sequence is kept for the next tx, since nothing is broadcast
+			if err = txm.signAndRecord(ctx, tc, sender, an, sn, txMsgs, gasLimit, gasPrice); err != nil {
+				return err
+			}
+			continue
+		}
+
+		txHash, timeoutHeight, err2 := txm.signAndBroadcast(ctx, tc, sender, an, sn, txMsgs, gasLimit, gasPrice)
+		if err2 != nil {
+			return err2
+		}
+		reserved = false
+		txm.metrics.observeBatch(len(txMsgs))
+
+		txm.wg.Add(1)
+		go func(an, sn uint64) {
+			defer txm.wg.Done()
+			txm.confirmAndBump(ctx, tc, sender, an, sn, txMsgs, gasLimit, gasPrice, txHash, timeoutHeight)
+		}(an, sn)
+	}
+	return nil
+}
+
+// markOversized marks msgs which exceed the tx limits on their own as errored.
+func (txm *Txm) markOversized(ctx context.Context, oversized client.SimMsgs) error {
+	if len(oversized) == 0 {
+		return nil
+	}
+	ids := oversized.GetSimMsgsIDs()
+	txm.lggr.Errorw("msgs exceed the gas or byte limit of a tx on their own, marking errored", "ids", ids)
+	if err := txm.orm.UpdateMsgsErrored(ctx, ids, db.Oversized, nil, nil); err != nil {
+		txm.lggr.Errorw("unable to mark oversized msgs as errored", "err", err, "ids", ids)
 		return err
 	}
-	gasLimit := s.GasInfo.GasUsed
+	txm.events.publish(ids, adapters.MsgEvent{State: db.Errored, Reason: ptr(db.Oversized)})
+	return nil
+}
+
+// confirmAndBump waits for the tx with txHash to be confirmed. Each time it times out, the msgs are re-signed
+// with the same sequence at a bumped gas price and rebroadcast, up to MaxGasBumpAttempts times, after which
+// they are marked as errored. The sequence is released once done.
+func (txm *Txm) confirmAndBump(ctx context.Context, tc client.ReaderWriter, sender sdk.AccAddress, an, sn uint64, msgs client.SimMsgs, gasLimit uint64, gasPrice sdk.DecCoin, txHash string, timeoutHeight int64) {
+	timedOut := false
+	defer func() {
+		txm.nonces.release(sender, sn, !timedOut)
+		// Sender may be able to send another batch now
+		txm.triggerNewMsg()
+	}()
+
+	ids := msgs.GetSimMsgsIDs()
+	for attempt := int64(0); ; attempt++ {
+		confirmed, err := txm.pollTx(ctx, tc, sender.String(), txHash, ids, timeoutHeight, txm.cfg.ConfirmPollPeriod())
+		if err != nil {
+			txm.lggr.Errorw("error confirming tx", "err", err, "hash", txHash)
+			return
+		}
+		if confirmed {
+			return
+		}
+		if attempt >= txm.cfg.MaxGasBumpAttempts() {
+			txm.lggr.Errorw("unable to confirm tx after timeout period and gas bumps, marking errored", "hash", txHash, "attempts", attempt)
+			timedOut = true
+			_ = txm.markTimedOut(ctx, ids, txHash)
+			return
+		}
+		bumpedGasPrice, err := txm.bumpGasPrice(gasPrice)
+		if err != nil {
+			txm.lggr.Errorw("unable to bump gas price, marking errored", "err", err, "hash", txHash, "gasPrice", gasPrice.String())
+			timedOut = true
+			_ = txm.markTimedOut(ctx, ids, txHash)
+			return
+		}
+		// The timed out tx can no longer be included, so its sequence number is free to be reused.
+		bumpedTxHash, bumpedTimeoutHeight, err := txm.signAndBroadcast(ctx, tc, sender, an, sn, msgs, gasLimit, bumpedGasPrice)
+		if err != nil {
+			// Possible if the old tx was still in the mempool after all, in which case it may yet be
+			// included so keep polling for it.
+			txm.lggr.Warnw("unable to rebroadcast tx with bumped gas price", "err", err, "hash", txHash, "gasPrice", bumpedGasPrice.String())
+			continue
+		}
+		txm.lggr.Infow("rebroadcasted timed out tx with bumped gas price", "oldHash", txHash, "hash", bumpedTxHash,
+			"oldGasPrice", gasPrice.String(), "gasPrice", bumpedGasPrice.String(), "attempt", attempt+1)
+		txHash, gasPrice, timeoutHeight = bumpedTxHash, bumpedGasPrice, bumpedTimeoutHeight
+	}
+}
 
+// signTx signs msgs with the given sequence number and gas price, in a tx which times out BlocksUntilTxTimeout
+// after the latest height. Returns the signed tx and its timeout height.
+func (txm *Txm) signTx(ctx context.Context, tc client.ReaderWriter, sender sdk.AccAddress, an, sn uint64, msgs client.SimMsgs, gasLimit uint64, gasPrice sdk.DecCoin) ([]byte, uint64, error) {
 	lb, err := tc.LatestBlock(ctx)
 	if err != nil {
 		txm.lggr.Warnw("unable to get latest block", "err", err, "from", sender.String())
 		// Assume transient api issue and retry.
-		return err
+		return nil, 0, err
 	}
 	header, timeout := lb.SdkBlock.Header.Height, txm.cfg.BlocksUntilTxTimeout()
 	if header < 0 {
-		return fmt.Errorf("invalid negative header height: %d", header)
+		return nil, 0, fmt.Errorf("invalid negative header height: %d", header)
 	} else if timeout < 0 {
-		return fmt.Errorf("invalid negative blocks until tx timeout: %d", timeout)
+		return nil, 0, fmt.Errorf("invalid negative blocks until tx timeout: %d", timeout)
 	}
 	timeoutHeight := uint64(header) + uint64(timeout)
-	signedTx, err := tc.CreateAndSign(simResults.Succeeded.GetMsgs(), an, sn, gasLimit, txm.cfg.GasLimitMultiplier(),
-		gasPrice, NewKeyWrapper(txm.keystoreAdapter, sender.String()), timeoutHeight)
+	var feeGranter sdk.AccAddress
+	if g := txm.cfg.FeeGranter(); g != "" {
+		feeGranter, err = sdk.AccAddressFromBech32(g)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid fee granter: %w", err)
+		}
+	}
+	signedTx, err := tc.CreateAndSign(msgs.GetMsgs(), an, sn, gasLimit, txm.cfg.GasLimitMultiplier(),
+		gasPrice, feeGranter, NewKeyWrapper(txm.keystoreAdapter, sender.String()), timeoutHeight)
 	if err != nil {
 		txm.lggr.Errorw("unable to sign tx", "err", err, "from", sender.String())
+		return nil, 0, err
+	}
+	return signedTx, timeoutHeight, nil
+}
+
+// signAndRecord signs msgs like signAndBroadcast, but instead of broadcasting the tx marks the msgs as
+// simulated with the gas used by the batch. Used in ShadowMode.
+func (txm *Txm) signAndRecord(ctx context.Context, tc client.ReaderWriter, sender sdk.AccAddress, an, sn uint64, msgs client.SimMsgs, gasLimit uint64, gasPrice sdk.DecCoin) error {
+	signedTx, _, err := txm.signTx(ctx, tc, sender, an, sn, msgs, gasLimit, gasPrice)
+	if err != nil {
 		return err
 	}
+	txHash := strings.ToUpper(hex.EncodeToString(tmhash.Sum(signedTx)))
+	ids := msgs.GetSimMsgsIDs()
+	if err = txm.orm.UpdateMsgsSimulated(ctx, ids, int64(gasLimit)); err != nil {
+		txm.lggr.Errorw("unable to mark msgs as simulated", "err", err, "from", sender.String())
+		return err
+	}
+	txm.lggr.Infow("shadow mode, not broadcasting tx", "from", sender, "msgs", msgs, "gasLimit", gasLimit, "gasPrice", gasPrice.String(), "hash", txHash)
+	txm.metrics.observeBatch(len(msgs))
+	txm.events.publish(ids, adapters.MsgEvent{State: db.Simulated})
+	return nil
+}
+
+// signAndBroadcast signs msgs with the given sequence number and gas price, then broadcasts the tx
+// and marks the msgs as broadcasted. Returns the hash of the broadcasted tx, and its timeout height.
+func (txm *Txm) signAndBroadcast(ctx context.Context, tc client.ReaderWriter, sender sdk.AccAddress, an, sn uint64, msgs client.SimMsgs, gasLimit uint64, gasPrice sdk.DecCoin) (string, int64, error) {
+	signedTx, timeoutHeight, err := txm.signTx(ctx, tc, sender, an, sn, msgs, gasLimit, gasPrice)
+	if err != nil {
+		return "", 0, err
+	}
 
 	// We need to ensure that we either broadcast successfully and mark the tx as
 	// broadcasted OR we do not broadcast successfully and we do not mark it as broadcasted.
 	// We do this by first marking it broadcasted then rolling back if the broadcast api call fails.
 	// There is still a small chance of network failure or node/db crash after broadcasting but before committing the tx,
 	// in which case the msgs would be picked up again and re-broadcast, ensuring at-least once delivery.
-	var resp *txtypes.BroadcastTxResponse
-	err = txm.orm.Transaction(ctx, func(orm *ORM) error {
-		txHash := strings.ToUpper(hex.EncodeToString(tmhash.Sum(signedTx)))
-		err = orm.UpdateMsgs(ctx, simResults.Succeeded.GetSimMsgsIDs(), db.Broadcasted, &txHash)
+	txHash := strings.ToUpper(hex.EncodeToString(tmhash.Sum(signedTx)))
+	err = txm.orm.Transaction(ctx, func(orm Storage) error {
+		err := orm.UpdateMsgsBroadcasted(ctx, msgs.GetSimMsgsIDs(), txHash, int64(timeoutHeight))
 		if err != nil {
 			return err
 		}
 
-		txm.lggr.Infow("broadcasting tx", "from", sender, "msgs", simResults.Succeeded, "gasLimit", gasLimit, "gasPrice", gasPrice.String(), "timeoutHeight", timeoutHeight, "hash", txHash)
-		resp, err = tc.Broadcast(ctx, signedTx, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+		txm.lggr.Infow("broadcasting tx", "from", sender, "msgs", msgs, "gasLimit", gasLimit, "gasPrice", gasPrice.String(), "timeoutHeight", timeoutHeight, "hash", txHash)
+		resp, err := tc.Broadcast(ctx, signedTx, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+		if errors.Is(err, client.ErrTxInMempool) {
+			// Broadcast before, e.g. if committing failed after a previous broadcast, so this is as good as broadcasted.
+			txm.lggr.Infow("tx already in mempool", "from", sender, "hash", txHash)
+			return nil
+		}
 		if err != nil {
 			// Rollback marking as broadcasted
-			// Note can happen if the node's mempool is full, where we expect errCode 20.
+			// Note can happen if the node's mempool is full, with client.ErrMempoolFull.
+			txm.metrics.incBroadcastErrors(err)
 			return err
 		}
 		if resp.TxResponse == nil {
@@ -366,84 +711,223 @@ func (txm *Txm) sendMsgBatchFromAddress(ctx context.Context, gasPrice sdk.DecCoi
 	if err != nil {
 		txm.lggr.Errorw("error broadcasting tx", "err", err, "from", sender.String())
 		// Was unable to broadcast, retry on next poll
-		return err
+		return "", 0, err
 	}
+	txm.events.publish(msgs.GetSimMsgsIDs(), adapters.MsgEvent{State: db.Broadcasted, TxHash: &txHash})
+	return txHash, int64(timeoutHeight), nil
+}
 
-	maxPolls, pollPeriod := txm.confirmPollConfig()
-	if err := txm.confirmTx(ctx, tc, resp.TxResponse.TxHash, simResults.Succeeded.GetSimMsgsIDs(), maxPolls, pollPeriod); err != nil {
-		txm.lggr.Errorw("error confirming tx", "err", err, "hash", resp.TxResponse.TxHash)
-		return err
+// bumpGasPrice returns the gas price to rebroadcast a timed out tx with, given the price it was last sent with.
+// The max gas price is used if the bump would exceed it, so long as that is still an increase.
+func (txm *Txm) bumpGasPrice(previous sdk.DecCoin) (sdk.DecCoin, error) {
+	current, err := txm.GasPrice()
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	gasToken := txm.cfg.GasToken()
+	maxGasPrice := sdk.NewDecCoinFromDec(gasToken, txm.cfg.MaxGasPrice())
+	if !maxGasPrice.IsPositive() {
+		// No cap
+		maxGasPrice = sdk.NewDecCoinFromDec(gasToken, sdk.MaxSortableDec)
 	}
+	bumped, err := client.CalculateBumpGasPrice(txm.lggr, gasToken, current, previous, maxGasPrice, maxGasPrice,
+		sdk.NewDecCoinFromDec(gasToken, txm.cfg.GasBumpMin()), txm.cfg.GasBumpPercent())
+	if err != nil {
+		if errors.Is(err, fee.ErrBumpFeeExceedsLimit) && bumped.Amount.GT(previous.Amount) {
+			txm.lggr.Warnw("bumped gas price capped at max gas price", "err", err, "gasPrice", bumped.String())
+			return bumped, nil
+		}
+		return sdk.DecCoin{}, err
+	}
+	return bumped, nil
+}
 
-	return nil
+// confirmTx waits for txHash to be confirmed, marking the broadcasted msgs as errored if it timed out.
+func (txm *Txm) confirmTx(ctx context.Context, tc client.Reader, sender, txHash string, broadcasted []int64, timeoutHeight int64, pollPeriod time.Duration) error {
+	confirmed, err := txm.pollTx(ctx, tc, sender, txHash, broadcasted, timeoutHeight, pollPeriod)
+	if err != nil {
+		return err
+	}
+	if confirmed {
+		return nil
+	}
+	txm.lggr.Errorw("tx was not included before its timeout height, marking errored", "hash", txHash, "timeoutHeight", timeoutHeight)
+	return txm.markTimedOut(ctx, broadcasted, txHash)
 }
 
-func (txm *Txm) confirmPollConfig() (maxPolls int, pollPeriod time.Duration) {
-	blocks := txm.cfg.BlocksUntilTxTimeout()
-	blockPeriod := txm.cfg.BlockRate()
-	pollPeriod = txm.cfg.ConfirmPollPeriod()
-	if pollPeriod == 0 {
-		// don't divide by zero
-		maxPolls = 1
+// subscribedPollEvery is how many polls are skipped while subscribed to a tx, in case the subscription
+// silently missed it.
+const subscribedPollEvery = 5
+
+// pollTx waits for txHash, marking the broadcasted msgs as confirmed once it is found,
+// or as errored if it was included but failed to execute.
+// Returns false if the tx timed out, in which case the caller decides how to handle it.
+// The sender is only used for metrics, and may be empty if unknown.
+func (txm *Txm) pollTx(ctx context.Context, tc client.Reader, sender, txHash string, broadcasted []int64, timeoutHeight int64, pollPeriod time.Duration) (bool, error) {
+	tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, pollPeriod)
+	if err != nil || tx == nil {
+		return false, err
+	}
+	txm.metrics.observeIncluded(sender, tx)
+	if code := tx.TxResponse.Code; code != 0 {
+		// Included, so the sequence was used, but the msgs did not execute.
+		txm.lggr.Errorw("tx failed to execute, marking errored", "hash", txHash, "msgs", broadcasted, "code", code, "log", tx.TxResponse.RawLog)
+		err = txm.orm.UpdateMsgsErrored(ctx, broadcasted, db.ExecutionFailed, &code, &tx.TxResponse.RawLog)
+		if err != nil {
+			return false, err
+		}
+		txm.events.publish(broadcasted, adapters.MsgEvent{State: db.Errored, TxHash: &txHash, Height: tx.TxResponse.Height,
+			Reason: ptr(db.ExecutionFailed), ErrorCode: &code, ErrorLog: &tx.TxResponse.RawLog})
+		return true, nil
+	}
+
+	txm.lggr.Infow("successfully sent batch", "hash", txHash, "msgs", broadcasted)
+	// If confirmed mark these as completed.
+	err = txm.orm.UpdateMsgs(ctx, broadcasted, db.Confirmed, nil)
+	if err != nil {
+		return false, err
+	}
+	txm.events.publish(broadcasted, adapters.MsgEvent{State: db.Confirmed, TxHash: &txHash, Height: tx.TxResponse.Height})
+	if confirmed, err := txm.orm.GetMsgs(ctx, broadcasted...); err != nil {
+		txm.lggr.Warnw("unable to read confirmed msgs for metrics", "err", err, "hash", txHash)
 	} else {
-		maxPolls = int((time.Duration(blocks) * blockPeriod) / pollPeriod)
+		txm.metrics.observeConfirmed(confirmed, time.Now())
 	}
-	return
+	return true, nil
 }
 
-func (txm *Txm) confirmTx(ctx context.Context, tc client.Reader, txHash string, broadcasted []int64, maxPolls int, pollPeriod time.Duration) error {
-	// We either mark these broadcasted txes as confirmed or errored.
+// waitForTx waits for txHash to be included, and returns it. Returns nil once the chain is past timeoutHeight
+// without the tx having been included, since it can then never be included. Also returns nil if the tx
+// could not be looked up for BlocksUntilTxTimeout blocks at the expected BlockRate.
+// Inclusion is signalled by a websocket subscription when available, so polling is only a fallback:
+// it happens every subscribedPollEvery periods while subscribed, and every period once the subscription ends.
+func (txm *Txm) waitForTx(ctx context.Context, tc client.Reader, txHash string, timeoutHeight int64, pollPeriod time.Duration) (*txtypes.GetTxResponse, error) {
+	// We either mark these broadcasted txes as confirmed or report them as timed out.
 	// Confirmed: we see the txhash onchain. There are no reorgs in cosmos chains.
-	// Errored: we do not see the txhash onchain after waiting for N blocks worth
-	// of time (plus a small buffer to account for block time variance) where N
-	// is TimeoutHeight - HeightAtBroadcast. In other words, if we wait for that long
-	// and the tx is not confirmed, we know it has timed out.
-	for tries := 0; tries < maxPolls; tries++ {
+	// Timed out: we see a block past the tx's timeout height, then still do not see the txhash onchain.
+	// Block times vary, so we compare heights rather than waiting for a fixed period.
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	included, err := tc.SubscribeTx(subCtx, txHash)
+	if err != nil {
+		txm.lggr.Debugw("unable to subscribe to tx, polling instead", "err", err, "hash", txHash)
+		included = nil
+	}
+	// If the tx can not be looked up, time out once it would have timed out at the expected block rate,
+	// rather than retrying forever.
+	deadline := time.Now().Add(time.Duration(txm.cfg.BlocksUntilTxTimeout()) * txm.cfg.BlockRate())
+	for tries := 1; ; tries++ {
 		// Jitter in-case we're confirming multiple txes in parallel for different keys
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
+		case _, ok := <-included:
+			if !ok {
+				txm.lggr.Debugw("tx subscription ended, polling instead", "hash", txHash)
+			}
+			// Either way, the subscription is done
+			included = nil
 		case <-time.After(utils.WithJitter(pollPeriod)):
+			if included != nil && tries%subscribedPollEvery != 0 {
+				continue
+			}
+		}
+		// Get the height before looking for the tx, so that if it is past the timeout height
+		// and the tx is not found, the tx can never be included.
+		lb, err := tc.LatestBlock(ctx)
+		if err != nil {
+			if time.Now().After(deadline) {
+				txm.lggr.Errorw("unable to get latest block until past the timeout period, giving up", "err", err, "hash", txHash)
+				return nil, nil
+			}
+			txm.lggr.Errorw("unable to get latest block, still confirming", "err", err, "hash", txHash)
+			continue
 		}
+		height := lb.SdkBlock.Header.Height
 		// Confirm that this tx is onchain, ensuring the sequence number has incremented
 		// so we can build a new batch
 		tx, err := tc.Tx(ctx, txHash)
 		if err != nil {
-			if strings.Contains(err.Error(), "not found") {
-				txm.lggr.Infow("txhash not found yet, still confirming", "hash", txHash)
-			} else {
+			if !errors.Is(err, client.ErrTxNotFound) {
+				if time.Now().After(deadline) {
+					txm.lggr.Errorw("error looking for hash of tx until past the timeout period, giving up", "err", err, "hash", txHash)
+					return nil, nil
+				}
 				txm.lggr.Errorw("error looking for hash of tx", "err", err, "hash", txHash)
+				continue
+			}
+			if height > timeoutHeight {
+				return nil, nil
 			}
+			txm.lggr.Infow("txhash not found yet, still confirming", "hash", txHash, "height", height, "timeoutHeight", timeoutHeight)
 			continue
 		}
 		// Sanity check
 		if tx.TxResponse == nil || tx.TxResponse.TxHash != txHash {
+			if time.Now().After(deadline) {
+				txm.lggr.Errorw("unexpected responses for hash of tx until past the timeout period, giving up", "tx", tx, "hash", txHash)
+				return nil, nil
+			}
 			txm.lggr.Errorw("error looking for hash of tx, unexpected response", "tx", tx, "hash", txHash)
 			continue
 		}
+		return tx, nil
+	}
+}
 
-		txm.lggr.Infow("successfully sent batch", "hash", txHash, "msgs", broadcasted)
-		// If confirmed mark these as completed.
-		err = txm.orm.UpdateMsgs(ctx, broadcasted, db.Confirmed, nil)
-		if err != nil {
-			return err
+// markTimedOut marks msgs whose tx could not be confirmed as errored.
+func (txm *Txm) markTimedOut(ctx context.Context, broadcasted []int64, txHash string) error {
+	err := txm.orm.UpdateMsgsErrored(ctx, broadcasted, db.TimedOut, nil, nil)
+	if err != nil {
+		txm.lggr.Errorw("unable to mark timed out txes as errored", "err", err, "txes", broadcasted, "num", len(broadcasted))
+		return err
+	}
+	txm.events.publish(broadcasted, adapters.MsgEvent{State: db.Errored, TxHash: &txHash, Reason: ptr(db.TimedOut)})
+	return nil
+}
+
+// markSimulationFailed marks msgs which failed simulation as errored, recording the simulation error of each.
+func (txm *Txm) markSimulationFailed(ctx context.Context, failed client.SimMsgs) error {
+	logs := make([]*string, len(failed))
+	err := txm.orm.Transaction(ctx, func(orm Storage) error {
+		for i, m := range failed {
+			if m.Err != nil {
+				logs[i] = ptr(m.Err.Error())
+			}
+			if err := orm.UpdateMsgsErrored(ctx, []int64{m.ID}, db.SimulationFailed, nil, logs[i]); err != nil {
+				return err
+			}
 		}
 		return nil
-	}
-	txm.lggr.Errorw("unable to confirm tx after timeout period, marking errored", "hash", txHash)
-	// If we are unable to confirm the tx after the timeout period
-	// mark these msgs as errored
-	err := txm.orm.UpdateMsgs(ctx, broadcasted, db.Errored, nil)
+	})
 	if err != nil {
-		txm.lggr.Errorw("unable to mark timed out txes as errored", "err", err, "txes", broadcasted, "num", len(broadcasted))
 		return err
 	}
+	for i, m := range failed {
+		txm.events.publish([]int64{m.ID}, adapters.MsgEvent{State: db.Errored, Reason: ptr(db.SimulationFailed), ErrorLog: logs[i]})
+	}
 	return nil
 }
 
 // Enqueue enqueue a msg destined for the cosmos chain.
-func (txm *Txm) Enqueue(ctx context.Context, contractID string, msg sdk.Msg) (int64, error) {
-	typeURL, raw, err := txm.marshalMsg(msg)
+func (txm *Txm) Enqueue(ctx context.Context, contractID string, msg sdk.Msg, opts ...adapters.EnqueueOption) (int64, error) {
+	o := adapters.NewEnqueueOptions(opts...)
+	var coalesceKey *string
+	if o.Policy == adapters.Coalesce {
+		if o.CoalesceKey == "" {
+			return 0, errors.New("coalesce key is required")
+		}
+		coalesceKey = &o.CoalesceKey
+	}
+	if o.Grantee != "" {
+		grantee, err2 := sdk.AccAddressFromBech32(o.Grantee)
+		if err2 != nil {
+			return 0, fmt.Errorf("invalid grantee %s: %w", o.Grantee, err2)
+		}
+		exec := authz.NewMsgExec(grantee, []sdk.Msg{msg})
+		msg = &exec
+	}
+	typeURL, raw, _, err := txm.marshalMsg(msg)
 	if err != nil {
 		return 0, err
 	}
@@ -454,24 +938,101 @@ func (txm *Txm) Enqueue(ctx context.Context, contractID string, msg sdk.Msg) (in
 	// and must be fast, so we do the minimum.
 
 	var id int64
-	err = txm.orm.Transaction(ctx, func(orm *ORM) (err error) {
-		// cancel any unstarted msgs (normally just one)
-		err = txm.orm.UpdateMsgsContract(ctx, contractID, db.Unstarted, db.Errored)
+	var cancelled []int64
+	var reason db.Reason
+	err = txm.orm.Transaction(ctx, func(orm Storage) (err error) {
+		switch o.Policy {
+		case adapters.ReplaceLatest:
+			// cancel any unstarted msgs (normally just one)
+			reason = db.Replaced
+			cancelled, err = orm.CancelUnstartedMsgs(ctx, contractID, nil, reason)
+		case adapters.Coalesce:
+			reason = db.Coalesced
+			cancelled, err = orm.CancelUnstartedMsgs(ctx, contractID, coalesceKey, reason)
+		case adapters.Append:
+		default:
+			return fmt.Errorf("unknown queue policy: %d", o.Policy)
+		}
 		if err != nil {
 			return err
 		}
-		id, err = txm.orm.InsertMsg(ctx, contractID, typeURL, raw)
+		if len(cancelled) > 0 {
+			txm.lggr.Debugw("cancelled unstarted msgs", "contractID", contractID, "ids", cancelled)
+		}
+		id, err = orm.InsertMsgWithKey(ctx, contractID, coalesceKey, typeURL, raw)
 		return err
 	})
+	if err == nil {
+		txm.events.publish(cancelled, adapters.MsgEvent{State: db.Errored, Reason: &reason})
+		txm.events.publish([]int64{id}, adapters.MsgEvent{State: db.Unstarted})
+	}
 
 	txm.triggerNewMsg()
 
 	return id, err
 }
 
+// EnqueueAndWait enqueues msg like Enqueue, then blocks until it reaches a terminal state.
+func (txm *Txm) EnqueueAndWait(ctx context.Context, contractID string, msg sdk.Msg, opts ...adapters.EnqueueOption) (adapters.MsgEvent, error) {
+	// Subscribe first, so that no events are missed
+	events, unsubscribe := txm.Subscribe()
+	defer unsubscribe()
+	id, err := txm.Enqueue(ctx, contractID, msg, opts...)
+	if err != nil {
+		return adapters.MsgEvent{}, err
+	}
+	// Check the db now and then in case events were dropped
+	ticker := time.NewTicker(utils.WithJitter(txm.cfg.BlockRate()))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return adapters.MsgEvent{}, ctx.Err()
+		case <-txm.stop:
+			return adapters.MsgEvent{}, errors.New("txm stopped")
+		case e := <-events:
+			if e.ID == id && e.State.IsTerminal() {
+				return e, nil
+			}
+		case <-ticker.C:
+			ms, err := txm.orm.GetMsgs(ctx, id)
+			if err != nil {
+				txm.lggr.Warnw("unable to get msg", "err", err, "id", id)
+				continue
+			}
+			if len(ms) == 1 && ms[0].State.IsTerminal() {
+				return adapters.MsgEvent{ID: id, State: ms[0].State, TxHash: ms[0].TxHash, Reason: ms[0].Reason,
+					ErrorCode: ms[0].ErrorCode, ErrorLog: ms[0].ErrorLog}, nil
+			}
+		}
+	}
+}
+
+// SetSendingKeys replaces the extra keys which may send msgs on behalf of signer.
+func (txm *Txm) SetSendingKeys(signer string, keys []string) error {
+	if _, err := sdk.AccAddressFromBech32(signer); err != nil {
+		return fmt.Errorf("invalid signer %s: %w", signer, err)
+	}
+	for _, k := range keys {
+		if _, err := sdk.AccAddressFromBech32(k); err != nil {
+			return fmt.Errorf("invalid sending key %s: %w", k, err)
+		}
+	}
+	txm.keys.set(signer, keys)
+	if len(keys) > 0 {
+		txm.lggr.Infow("set sending keys", "signer", signer, "keys", keys)
+	}
+	return nil
+}
+
+// Subscribe returns a channel of msg state transitions, and a func to unsubscribe.
+func (txm *Txm) Subscribe() (<-chan adapters.MsgEvent, func()) {
+	return txm.events.subscribe()
+}
+
 func (txm *Txm) triggerNewMsg() {
 	select {
-	case <-txm.newMsgs:
+	case txm.newMsgs <- struct{}{}:
 	default:
 		// request is already queued
 	}
@@ -486,32 +1047,31 @@ func (e *ErrMsgUnsupported) Error() string {
 	return fmt.Sprintf("unsupported message type %T: %s", e.Msg, e.Msg)
 }
 
-func (txm *Txm) marshalMsg(msg sdk.Msg) (string, []byte, error) {
-	switch ms := msg.(type) {
-	case *wasmtypes.MsgExecuteContract:
-		_, err := sdk.AccAddressFromBech32(ms.Sender)
-		if err != nil {
-			txm.lggr.Errorw("failed to parse sender, skipping", "err", err, "sender", ms.Sender)
-			return "", nil, err
-		}
-
-	case *types.MsgSend:
-		_, err := sdk.AccAddressFromBech32(ms.FromAddress)
-		if err != nil {
-			txm.lggr.Errorw("failed to parse sender, skipping", "err", err, "sender", ms.FromAddress)
-			return "", nil, err
-		}
-
-	default:
-		return "", nil, &ErrMsgUnsupported{Msg: msg}
+// marshalMsg returns the type URL and encoding of msg, along with the address of its signer.
+func (txm *Txm) marshalMsg(msg sdk.Msg) (typeURL string, raw []byte, sender string, err error) {
+	t, ok := adapters.GetMsgType(sdk.MsgTypeURL(msg))
+	if !ok {
+		return "", nil, "", &ErrMsgUnsupported{Msg: msg}
+	}
+	sender, err = t.Sender(msg)
+	if err != nil {
+		return "", nil, "", &ErrMsgUnsupported{Msg: msg}
+	}
+	if _, err = sdk.AccAddressFromBech32(sender); err != nil {
+		txm.lggr.Errorw("failed to parse sender, skipping", "err", err, "sender", sender)
+		return "", nil, "", err
 	}
-	typeURL := sdk.MsgTypeURL(msg)
-	raw, err := proto.Marshal(msg)
+	raw, err = proto.Marshal(msg)
 	if err != nil {
 		txm.lggr.Errorw("failed to marshal msg, skipping", "err", err, "msg", msg)
-		return "", nil, err
+		return "", nil, "", err
 	}
-	return typeURL, raw, nil
+	return t.TypeURL, raw, sender, nil
+}
+
+// Accounts returns the bech32 addresses of the keys in the keystore.
+func (txm *Txm) Accounts(ctx context.Context) ([]string, error) {
+	return txm.keystoreAdapter.Accounts(ctx)
 }
 
 // GetMsgs returns any messages matching ids.
@@ -521,7 +1081,10 @@ func (txm *Txm) GetMsgs(ctx context.Context, ids ...int64) (adapters.Msgs, error
 
 // GasPrice returns the gas price from the estimator in the configured fee token.
 func (txm *Txm) GasPrice() (sdk.DecCoin, error) {
-	prices := txm.gpe.GasPrices()
+	prices, err := txm.gpe.GasPrices()
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
 	gasPrice, ok := prices[txm.cfg.GasToken()]
 	if !ok {
 		return sdk.DecCoin{}, errors.New("unexpected empty gas price")
@@ -536,3 +1099,5 @@ func (txm *Txm) Close() error {
 		return nil
 	})
 }
+
+func ptr[T any](t T) *T { return &t }
@@ -2,6 +2,7 @@ package txm
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"slices"
@@ -9,11 +10,17 @@ import (
 	"time"
 
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	cmttypes "github.com/cometbft/cometbft/types"
 	tmservicetypes "github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -22,6 +29,9 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
+	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters"
+	_ "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters/injective" // registers MsgTransmit
+	chaintypes "github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/adapters/injective/types"
 	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client"
 	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/client/mocks"
 	"github.com/smartcontractkit/chainlink-cosmos/pkg/cosmos/config"
@@ -38,26 +48,31 @@ func generateExecuteMsg(msg []byte, from, to cosmostypes.AccAddress) cosmostypes
 }
 
 func TestTxm(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		newStorage func(t *testing.T, chainID string) Storage
+	}{
+		{"memory", func(_ *testing.T, chainID string) Storage { return NewMemoryStorage(chainID) }},
+		{"postgres", func(t *testing.T, chainID string) Storage { return NewORM(chainID, NewDB(t)) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			testTxm(t, tt.newStorage)
+		})
+	}
+}
+
+// testTxm runs the Txm tests on the Storage of newStorage.
+func testTxm(t *testing.T, newStorage func(t *testing.T, chainID string) Storage) {
 	ctx := tests.Context(t)
 	lggr := logger.Test(t)
-	db := NewDB(t)
 	ks := newKeystore(4)
+	sender1, sender2, contract, contract2 := ks.address(0), ks.address(1), ks.address(2), ks.address(3)
 
 	adapter := newKeystoreAdapter(ks, "wasm")
 	accounts, err := adapter.Accounts(ctx)
 	require.NoError(t, err)
-	require.Equal(t, len(accounts), 4)
+	require.ElementsMatch(t, []string{sender1.String(), sender2.String(), contract.String(), contract2.String()}, accounts)
 
-	sender1, err := cosmostypes.AccAddressFromBech32(accounts[0])
-	require.NoError(t, err)
-	sender2, err := cosmostypes.AccAddressFromBech32(accounts[1])
-	require.NoError(t, err)
-	contract, err := cosmostypes.AccAddressFromBech32(accounts[2])
-	require.NoError(t, err)
-	contract2, err := cosmostypes.AccAddressFromBech32(accounts[3])
-	require.NoError(t, err)
-
-	chainID := RandomChainID()
 	two := int64(2)
 	gasToken := "ucosm"
 	cfg := &config.TOMLConfig{Chain: config.Chain{
@@ -65,68 +80,274 @@ func TestTxm(t *testing.T) {
 		GasToken:        &gasToken,
 	}}
 	cfg.SetDefaults()
-	gpe := client.NewMustGasPriceEstimator([]client.GasPricesEstimator{
+	gpe := client.NewComposedGasPriceEstimator([]client.GasPricesEstimator{
 		client.NewFixedGasPriceEstimator(map[string]cosmostypes.DecCoin{
 			cfg.GasToken(): cosmostypes.NewDecCoinFromDec(cfg.GasToken(), cosmostypes.MustNewDecFromStr("0.01")),
 		},
-			lggr.(logger.SugaredLogger),
+			logger.Sugared(lggr),
 		),
-	}, lggr)
+	}, nil, lggr)
+	// Each txm has its own chain, so that they do not see each other's msgs in a shared database.
+	newTxm := func(t *testing.T, tc func() (client.ReaderWriter, error), cfg config.Config, ks *keystore) *Txm {
+		chainID := RandomChainID()
+		return NewTxmWithStorage(newStorage(t, chainID), tc, gpe, chainID, cfg, ks, lggr)
+	}
 
 	t.Run("single msg", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := mocks.NewReaderWriter(t)
+		tc := newReaderWriter(t)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfg, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfg, loopKs)
 
 		// Enqueue a single msg, then send it in a batch
 		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), sender1, contract))
 		require.NoError(t, err)
-		tc.On("Account", mock.Anything).Return(uint64(0), uint64(0), nil)
-		tc.On("BatchSimulateUnsigned", mock.Anything, mock.Anything).Return(&client.BatchSimResults{
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil)
+		tc.On("BatchSimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&client.BatchSimResults{
 			Failed: nil,
 			Succeeded: client.SimMsgs{{ID: id1, Msg: &wasmtypes.MsgExecuteContract{
 				Sender: sender1.String(),
 				Msg:    []byte(`1`),
 			}}},
 		}, nil)
-		tc.On("SimulateUnsigned", mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
 			GasUsed: 1_000_000,
 		}}, nil)
-		tc.On("LatestBlock").Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
 			Header: tmservicetypes.Header{Height: 1},
 		}}, nil)
-		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
 
 		txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
-		tc.On("Broadcast", mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil)
-		tc.On("Tx", mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil)
-		txm.sendMsgBatch(tests.Context(t))
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil)
+		tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil)
+		sendMsgBatchAndWait(tests.Context(t), txm)
 
 		// Should be in completed state
 		completed, err := txm.orm.GetMsgs(ctx, id1)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(completed))
-		assert.Equal(t, completed[0].State, cosmosdb.Confirmed)
+		assert.Equal(t, cosmosdb.Confirmed, completed[0].State)
+	})
+
+	t.Run("queue policies", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+		enqueue := func(msg string, opts ...adapters.EnqueueOption) int64 {
+			id, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(msg), sender1, contract), opts...)
+			require.NoError(t, err)
+			return id
+		}
+
+		replaced := enqueue(`0`)
+		appended := enqueue(`1`, adapters.WithAppend())
+		coalesced := enqueue(`2`, adapters.WithCoalesceKey("a"))
+		keyB := enqueue(`3`, adapters.WithCoalesceKey("b"))
+		keyA := enqueue(`4`, adapters.WithCoalesceKey("a"))
+		_, err = txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`5`), sender1, contract), adapters.WithCoalesceKey(""))
+		require.Error(t, err)
+
+		ms, err := txm.orm.GetMsgs(ctx, replaced, appended, coalesced, keyB, keyA)
+		require.NoError(t, err)
+		require.Len(t, ms, 5)
+		states := map[int64]cosmosdb.State{}
+		reasons := map[int64]*cosmosdb.Reason{}
+		for _, m := range ms {
+			states[m.ID] = m.State
+			reasons[m.ID] = m.Reason
+		}
+		assert.Equal(t, cosmosdb.Unstarted, states[replaced])
+		assert.Equal(t, cosmosdb.Unstarted, states[appended])
+		assert.Equal(t, cosmosdb.Errored, states[coalesced])
+		assert.Equal(t, ptr(cosmosdb.Coalesced), reasons[coalesced])
+		assert.Equal(t, cosmosdb.Unstarted, states[keyB])
+		assert.Equal(t, cosmosdb.Unstarted, states[keyA])
+
+		// Replacing cancels everything unstarted for the contract
+		latest := enqueue(`6`)
+		ms, err = txm.orm.GetMsgs(ctx, replaced, appended, keyB, keyA, latest)
+		require.NoError(t, err)
+		for _, m := range ms {
+			if m.ID == latest {
+				assert.Equal(t, cosmosdb.Unstarted, m.State)
+				continue
+			}
+			assert.Equal(t, cosmosdb.Errored, m.State)
+			assert.Equal(t, ptr(cosmosdb.Replaced), m.Reason)
+		}
+	})
+
+	t.Run("enqueue and wait", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+		events, unsubscribe := txm.Subscribe()
+		defer unsubscribe()
+
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil)
+		tc.On("BatchSimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, msgs client.SimMsgs, _ uint64) *client.BatchSimResults {
+			return &client.BatchSimResults{Succeeded: msgs}
+		}, nil)
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+			GasUsed: 1_000_000,
+		}}, nil)
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil)
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
+		txHash := "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"
+		txResp := &cosmostypes.TxResponse{TxHash: txHash, Height: 5}
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil)
+		tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil)
+		require.NoError(t, txm.Start(ctx))
+		t.Cleanup(func() { require.NoError(t, txm.Close()) })
+
+		waitCtx, cancel := context.WithTimeout(ctx, tests.WaitTimeout(t))
+		defer cancel()
+		e, err := txm.EnqueueAndWait(waitCtx, contract.String(), generateExecuteMsg([]byte(`1`), sender1, contract))
+		require.NoError(t, err)
+		assert.Equal(t, cosmosdb.Confirmed, e.State)
+		require.NotNil(t, e.TxHash)
+		assert.Equal(t, txHash, *e.TxHash)
+		assert.Equal(t, int64(5), e.Height)
+
+		var states []cosmosdb.State
+		for len(events) > 0 {
+			ev := <-events
+			assert.Equal(t, e.ID, ev.ID)
+			states = append(states, ev.State)
+		}
+		assert.Equal(t, []cosmosdb.State{cosmosdb.Unstarted, cosmosdb.Started, cosmosdb.Broadcasted, cosmosdb.Confirmed}, states)
+	})
+
+	t.Run("sending keys", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+
+		require.NoError(t, txm.SetSendingKeys(sender1.String(), []string{sender2.String()}))
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), sender1, contract))
+		require.NoError(t, err)
+		// sender1 already has a batch in progress, so sender2 executes on its behalf
+		txm.workers[sender1.String()] = struct{}{}
+
+		isExec := mock.MatchedBy(func(msgs []cosmostypes.Msg) bool {
+			exec, ok := msgs[0].(*authz.MsgExec)
+			return len(msgs) == 1 && ok && exec.Grantee == sender2.String()
+		})
+		mockSendBatch(tc, sender2, isExec)
+		sendMsgBatchAndWait(ctx, txm)
+
+		ms, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		require.Len(t, ms, 1)
+		assert.Equal(t, cosmosdb.Confirmed, ms[0].State)
+	})
+
+	t.Run("shadow mode", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		shadow := true
+		cfgShadow := &config.TOMLConfig{Chain: config.Chain{ShadowMode: &shadow}}
+		cfgShadow.SetDefaults()
+		txm := newTxm(t, tcFn, cfgShadow, newKeystore(1))
+
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), sender1, contract))
+		require.NoError(t, err)
+		mockSendBatch(tc, sender1, mock.Anything)
+		sendMsgBatchAndWait(ctx, txm)
+		tc.AssertNotCalled(t, "Broadcast", mock.Anything, mock.Anything, mock.Anything)
+
+		ms, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		require.Len(t, ms, 1)
+		assert.Equal(t, cosmosdb.Simulated, ms[0].State)
+		assert.Equal(t, ptr(int64(1_000_000)), ms[0].SimulatedGas)
+		assert.Nil(t, ms[0].TxHash)
+	})
+
+	t.Run("grantee", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+
+		// sender2 executes on behalf of granter, whose key the node does not hold
+		granter := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), granter, contract), adapters.WithGrantee(sender2.String()))
+		require.NoError(t, err)
+
+		isExec := mock.MatchedBy(func(msgs []cosmostypes.Msg) bool {
+			exec, ok := msgs[0].(*authz.MsgExec)
+			return len(msgs) == 1 && ok && exec.Grantee == sender2.String()
+		})
+		mockSendBatch(tc, sender2, isExec)
+		sendMsgBatchAndWait(ctx, txm)
+
+		ms, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		require.Len(t, ms, 1)
+		assert.Equal(t, cosmostypes.MsgTypeURL(&authz.MsgExec{}), ms[0].Type)
+		assert.Equal(t, cosmosdb.Confirmed, ms[0].State)
+
+		_, err = txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), granter, contract), adapters.WithGrantee("invalid"))
+		require.Error(t, err)
+	})
+
+	t.Run("grantee with sending keys", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+
+		// sender1 executes on behalf of granter directly, in place of the busy grantee sender2
+		granter := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+		require.NoError(t, txm.SetSendingKeys(sender2.String(), []string{sender1.String()}))
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), granter, contract), adapters.WithGrantee(sender2.String()))
+		require.NoError(t, err)
+		txm.workers[sender2.String()] = struct{}{}
+
+		isExec := mock.MatchedBy(func(msgs []cosmostypes.Msg) bool {
+			exec, ok := msgs[0].(*authz.MsgExec)
+			if len(msgs) != 1 || !ok || exec.Grantee != sender1.String() {
+				return false
+			}
+			// Not nested in another MsgExec
+			var execute wasmtypes.MsgExecuteContract
+			return len(exec.Msgs) == 1 && exec.Msgs[0].TypeUrl == cosmostypes.MsgTypeURL(&execute) &&
+				execute.Unmarshal(exec.Msgs[0].Value) == nil && execute.Sender == granter.String()
+		})
+		mockSendBatch(tc, sender1, isExec)
+		sendMsgBatchAndWait(ctx, txm)
+
+		ms, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		require.Len(t, ms, 1)
+		assert.Equal(t, cosmosdb.Confirmed, ms[0].State)
 	})
 
 	t.Run("two msgs different accounts", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := mocks.NewReaderWriter(t)
+		tc := newReaderWriter(t)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfg, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfg, loopKs)
 
 		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`0`), sender1, contract))
 		require.NoError(t, err)
 		id2, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), sender2, contract))
 		require.NoError(t, err)
 
-		tc.On("Account", mock.Anything).Return(uint64(0), uint64(0), nil).Once()
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil).Once()
 		// Note this must be arg dependent, we don't know which order
 		// the procesing will happen in (map iteration by from address).
-		tc.On("BatchSimulateUnsigned", client.SimMsgs{
+		tc.On("BatchSimulateUnsigned", mock.Anything, client.SimMsgs{
 			{
 				ID: id2,
 				Msg: &wasmtypes.MsgExecuteContract{
@@ -148,32 +369,33 @@ func TestTxm(t *testing.T) {
 				},
 			},
 		}, nil).Once()
-		tc.On("SimulateUnsigned", mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
 			GasUsed: 1_000_000,
 		}}, nil).Once()
-		tc.On("LatestBlock").Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
 			Header: tmservicetypes.Header{Height: 1},
-		}}, nil).Once()
-		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil).Once()
+		}}, nil).Twice()
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil).Once()
 		txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
-		tc.On("Broadcast", mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Once()
-		tc.On("Tx", mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Once()
-		txm.sendMsgBatch(tests.Context(t))
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Once()
+		tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Once()
+		sendMsgBatchAndWait(tests.Context(t), txm)
 
 		// Should be in completed state
 		completed, err := txm.orm.GetMsgs(ctx, id1, id2)
 		require.NoError(t, err)
 		require.Equal(t, 2, len(completed))
 		assert.Equal(t, cosmosdb.Errored, completed[0].State) // cancelled
+		assert.Equal(t, ptr(cosmosdb.Replaced), completed[0].Reason)
 		assert.Equal(t, cosmosdb.Confirmed, completed[1].State)
 	})
 
 	t.Run("two msgs different contracts", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := mocks.NewReaderWriter(t)
+		tc := newReaderWriter(t)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfg, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfg, loopKs)
 
 		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`0`), sender1, contract))
 		require.NoError(t, err)
@@ -183,10 +405,10 @@ func TestTxm(t *testing.T) {
 		senders := []string{sender1.String(), sender2.String()}
 		contracts := []string{contract.String(), contract2.String()}
 		for i := 0; i < 2; i++ {
-			tc.On("Account", mock.Anything).Return(uint64(0), uint64(0), nil).Once()
+			tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil).Once()
 			// Note this must be arg dependent, we don't know which order
 			// the procesing will happen in (map iteration by from address).
-			tc.On("BatchSimulateUnsigned", client.SimMsgs{
+			tc.On("BatchSimulateUnsigned", mock.Anything, client.SimMsgs{
 				{
 					ID: ids[i],
 					Msg: &wasmtypes.MsgExecuteContract{
@@ -208,18 +430,18 @@ func TestTxm(t *testing.T) {
 					},
 				},
 			}, nil).Once()
-			tc.On("SimulateUnsigned", mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+			tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
 				GasUsed: 1_000_000,
 			}}, nil).Once()
-			tc.On("LatestBlock").Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
 				Header: tmservicetypes.Header{Height: 1},
-			}}, nil).Once()
-			tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil).Once()
+			}}, nil).Twice()
+			tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil).Once()
 		}
 		txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
-		tc.On("Broadcast", mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Twice()
-		tc.On("Tx", mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Twice()
-		txm.sendMsgBatch(tests.Context(t))
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Twice()
+		tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Twice()
+		sendMsgBatchAndWait(tests.Context(t), txm)
 
 		// Should be in completed state
 		completed, err := txm.orm.GetMsgs(ctx, id1, id2)
@@ -229,27 +451,191 @@ func TestTxm(t *testing.T) {
 		assert.Equal(t, cosmosdb.Confirmed, completed[1].State)
 	})
 
+	t.Run("slow sender does not block others", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		loopKs := newKeystore(1)
+		txm := newTxm(t, tcFn, cfg, loopKs)
+
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`0`), sender1, contract))
+		require.NoError(t, err)
+		id2, err := txm.Enqueue(ctx, contract2.String(), generateExecuteMsg([]byte(`1`), sender2, contract2))
+		require.NoError(t, err)
+		ids := []int64{id1, id2}
+		senders := []string{sender1.String(), sender2.String()}
+		contracts := []string{contract.String(), contract2.String()}
+		signed := [][]byte{{0x01}, {0x02}}
+		txHashes := []string{
+			"4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A",
+			"DBC1B4C900FFE48D575B5DA5C638040125F65DB0FE3E24494B76EA986457D986",
+		}
+		for i := 0; i < 2; i++ {
+			msgs := client.SimMsgs{{ID: ids[i], Msg: &wasmtypes.MsgExecuteContract{
+				Sender:   senders[i],
+				Msg:      []byte(fmt.Sprintf(`%d`, i)),
+				Contract: contracts[i],
+			}}}
+			tc.On("BatchSimulateUnsigned", mock.Anything, msgs, mock.Anything).
+				Return(&client.BatchSimResults{Failed: nil, Succeeded: msgs}, nil).Once()
+			tc.On("CreateAndSign", msgs.GetMsgs(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(signed[i], nil).Once()
+			tc.On("Broadcast", mock.Anything, signed[i], mock.Anything).
+				Return(&txtypes.BroadcastTxResponse{TxResponse: &cosmostypes.TxResponse{TxHash: txHashes[i]}}, nil).Once()
+		}
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil).Twice()
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+			GasUsed: 1_000_000,
+		}}, nil).Twice()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil)
+		// The first sender's tx is only found once the second sender's msg is confirmed.
+		unblock := make(chan time.Time)
+		tc.On("Tx", mock.Anything, txHashes[0]).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: &cosmostypes.TxResponse{TxHash: txHashes[0]}}, nil).
+			WaitUntil(unblock).Once()
+		tc.On("Tx", mock.Anything, txHashes[1]).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: &cosmostypes.TxResponse{TxHash: txHashes[1]}}, nil).Once()
+		txm.sendMsgBatch(ctx)
+
+		require.Eventually(t, func() bool {
+			m, err := txm.orm.GetMsgs(ctx, id2)
+			require.NoError(t, err)
+			return m[0].State == cosmosdb.Confirmed
+		}, tests.WaitTimeout(t), 10*time.Millisecond)
+		m, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		assert.Equal(t, cosmosdb.Broadcasted, m[0].State)
+
+		close(unblock)
+		txm.wg.Wait()
+		m, err = txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		assert.Equal(t, cosmosdb.Confirmed, m[0].State)
+	})
+
 	t.Run("failed to confirm", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := mocks.NewReaderWriter(t)
-		tc.On("Tx", mock.Anything).Return(&txtypes.GetTxResponse{
-			Tx:         &txtypes.Tx{},
-			TxResponse: &cosmostypes.TxResponse{TxHash: "0x123"},
-		}, errors.New("not found")).Twice()
+		tc := newReaderWriter(t)
+		tc.On("Tx", mock.Anything, "0x123").Return(nil, client.ErrTxNotFound).Twice()
+		// The tx may still be included at its timeout height, but not after it
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 2},
+		}}, nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 3},
+		}}, nil).Once()
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfg, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfg, loopKs)
 		i, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x01})
 		require.NoError(t, err)
 		txh := "0x123"
 		require.NoError(t, txm.orm.UpdateMsgs(ctx, []int64{i}, cosmosdb.Started, &txh))
 		require.NoError(t, txm.orm.UpdateMsgs(ctx, []int64{i}, cosmosdb.Broadcasted, &txh))
-		err = txm.confirmTx(tests.Context(t), tc, txh, []int64{i}, 2, 1*time.Millisecond)
+		err = txm.confirmTx(tests.Context(t), tc, "", txh, []int64{i}, 2, 1*time.Millisecond)
+		require.NoError(t, err)
+		m, err := txm.orm.GetMsgs(ctx, i)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(m))
+		assert.Equal(t, cosmosdb.Errored, m[0].State)
+		assert.Equal(t, ptr(cosmosdb.TimedOut), m[0].Reason)
+	})
+
+	t.Run("failed to execute", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		txh := "0x123"
+		tc.On("Tx", mock.Anything, txh).Return(&txtypes.GetTxResponse{
+			Tx:         &txtypes.Tx{},
+			TxResponse: &cosmostypes.TxResponse{TxHash: txh, Code: 11, RawLog: "out of gas"},
+		}, nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil).Once()
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		txm := newTxm(t, tcFn, cfg, newKeystore(1))
+		i, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x01})
+		require.NoError(t, err)
+		require.NoError(t, txm.orm.UpdateMsgs(ctx, []int64{i}, cosmosdb.Started, nil))
+		require.NoError(t, txm.orm.UpdateMsgs(ctx, []int64{i}, cosmosdb.Broadcasted, &txh))
+		included, err := txm.pollTx(ctx, tc, "", txh, []int64{i}, 1, time.Millisecond)
 		require.NoError(t, err)
+		assert.True(t, included)
 		m, err := txm.orm.GetMsgs(ctx, i)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(m))
 		assert.Equal(t, cosmosdb.Errored, m[0].State)
+		assert.Equal(t, ptr(cosmosdb.ExecutionFailed), m[0].Reason)
+		assert.Equal(t, ptr(uint32(11)), m[0].ErrorCode)
+		assert.Equal(t, ptr("out of gas"), m[0].ErrorLog)
+	})
+
+	t.Run("gas bump on timeout", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		pollPeriod, err := commoncfg.NewDuration(time.Millisecond)
+		require.NoError(t, err)
+		blockRate, err := commoncfg.NewDuration(2 * time.Millisecond)
+		require.NoError(t, err)
+		one := int64(1)
+		cfgBump := &config.TOMLConfig{Chain: config.Chain{
+			BlockRate:            &blockRate,
+			BlocksUntilTxTimeout: &one,
+			ConfirmPollPeriod:    &pollPeriod,
+			GasToken:             &gasToken,
+			MaxGasBumpAttempts:   &one,
+		}}
+		cfgBump.SetDefaults()
+		loopKs := newKeystore(1)
+		txm := newTxm(t, tcFn, cfgBump, loopKs)
+
+		id1, err := txm.Enqueue(ctx, contract.String(), generateExecuteMsg([]byte(`1`), sender1, contract))
+		require.NoError(t, err)
+		msgs := client.SimMsgs{{ID: id1, Msg: &wasmtypes.MsgExecuteContract{
+			Sender:   sender1.String(),
+			Msg:      []byte(`1`),
+			Contract: contract.String(),
+		}}}
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil).Once()
+		tc.On("BatchSimulateUnsigned", mock.Anything, msgs, mock.Anything).
+			Return(&client.BatchSimResults{Failed: nil, Succeeded: msgs}, nil).Once()
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+			GasUsed: 1_000_000,
+		}}, nil).Once()
+		// The first tx times out once the chain is past its timeout height, and is rebroadcast.
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 2 + cfgBump.BlocksUntilTxTimeout()},
+		}}, nil)
+		originalGasPrice, err := txm.GasPrice()
+		require.NoError(t, err)
+		isGasPrice := func(price sdk.DecCoin) interface{} {
+			return mock.MatchedBy(func(p sdk.DecCoin) bool { return p.IsEqual(price) })
+		}
+		// First attempt is signed at the estimated price, the rebroadcast is signed at a bumped price.
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, isGasPrice(originalGasPrice), mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{0x01}, nil).Once()
+		bumpedGasPrice, err := txm.bumpGasPrice(originalGasPrice)
+		require.NoError(t, err)
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, isGasPrice(bumpedGasPrice), mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{0x02}, nil).Once()
+		txHash1 := "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"
+		txHash2 := "DBC1B4C900FFE48D575B5DA5C638040125F65DB0FE3E24494B76EA986457D986"
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: &cosmostypes.TxResponse{TxHash: txHash1}}, nil).Once()
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: &cosmostypes.TxResponse{TxHash: txHash2}}, nil).Once()
+		tc.On("Tx", mock.Anything, txHash1).Return(nil, client.ErrTxNotFound)
+		tc.On("Tx", mock.Anything, txHash2).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: &cosmostypes.TxResponse{TxHash: txHash2}}, nil).Once()
+		sendMsgBatchAndWait(tests.Context(t), txm)
+
+		m, err := txm.orm.GetMsgs(ctx, id1)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(m))
+		assert.Equal(t, cosmosdb.Confirmed, m[0].State)
+		require.NotNil(t, m[0].TxHash)
+		assert.Equal(t, txHash2, *m[0].TxHash)
 	})
 
 	t.Run("confirm any unconfirmed", func(t *testing.T) {
@@ -258,19 +644,22 @@ func TestTxm(t *testing.T) {
 		txHash1 := "0x1234"
 		txHash2 := "0x1235"
 		txHash3 := "0xabcd"
-		tc := mocks.NewReaderWriter(t)
-		tc.On("Tx", txHash1).Return(&txtypes.GetTxResponse{
+		tc := newReaderWriter(t)
+		tc.On("Tx", mock.Anything, txHash1).Return(&txtypes.GetTxResponse{
 			TxResponse: &cosmostypes.TxResponse{TxHash: txHash1},
 		}, nil).Once()
-		tc.On("Tx", txHash2).Return(&txtypes.GetTxResponse{
+		tc.On("Tx", mock.Anything, txHash2).Return(&txtypes.GetTxResponse{
 			TxResponse: &cosmostypes.TxResponse{TxHash: txHash2},
 		}, nil).Once()
-		tc.On("Tx", txHash3).Return(&txtypes.GetTxResponse{
+		tc.On("Tx", mock.Anything, txHash3).Return(&txtypes.GetTxResponse{
 			TxResponse: &cosmostypes.TxResponse{TxHash: txHash3},
 		}, nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfg, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfg, loopKs)
 
 		// Insert and broadcast 3 msgs with different txhashes.
 		id1, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x01})
@@ -304,7 +693,7 @@ func TestTxm(t *testing.T) {
 
 	t.Run("expired msgs", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := new(mocks.ReaderWriter)
+		tc := newReaderWriter(t)
 		timeout, err := commoncfg.NewDuration(1 * time.Millisecond)
 		require.NoError(t, err)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
@@ -315,17 +704,18 @@ func TestTxm(t *testing.T) {
 		}}
 		cfgShortExpiry.SetDefaults()
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfgShortExpiry, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfgShortExpiry, loopKs)
 
 		// Send a single one expired
 		id1, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x03})
 		require.NoError(t, err)
 		time.Sleep(1 * time.Millisecond)
-		txm.sendMsgBatch(tests.Context(t))
+		sendMsgBatchAndWait(tests.Context(t), txm)
 		// Should be marked errored
 		m, err := txm.orm.GetMsgs(ctx, id1)
 		require.NoError(t, err)
 		assert.Equal(t, cosmosdb.Errored, m[0].State)
+		assert.Equal(t, ptr(cosmosdb.Expired), m[0].Reason)
 
 		// Send a batch which is all expired
 		id2, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x03})
@@ -333,28 +723,30 @@ func TestTxm(t *testing.T) {
 		id3, err := txm.orm.InsertMsg(ctx, "blah", "", []byte{0x03})
 		require.NoError(t, err)
 		time.Sleep(1 * time.Millisecond)
-		txm.sendMsgBatch(tests.Context(t))
+		sendMsgBatchAndWait(tests.Context(t), txm)
 		require.NoError(t, err)
 		ms, err := txm.orm.GetMsgs(ctx, id2, id3)
 		require.NoError(t, err)
 		assert.Equal(t, cosmosdb.Errored, ms[0].State)
 		assert.Equal(t, cosmosdb.Errored, ms[1].State)
+		assert.Equal(t, ptr(cosmosdb.Expired), ms[0].Reason)
+		assert.Equal(t, ptr(cosmosdb.Expired), ms[1].Reason)
 	})
 
 	t.Run("started msgs", func(t *testing.T) {
 		ctx := tests.Context(t)
-		tc := new(mocks.ReaderWriter)
-		tc.On("Account", mock.Anything).Return(uint64(0), uint64(0), nil)
-		tc.On("SimulateUnsigned", mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+		tc := newReaderWriter(t)
+		tc.On("Account", mock.Anything, mock.Anything).Return(uint64(0), uint64(0), nil)
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
 			GasUsed: 1_000_000,
 		}}, nil)
-		tc.On("LatestBlock").Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
 			Header: tmservicetypes.Header{Height: 1},
 		}}, nil)
-		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
+		tc.On("CreateAndSign", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
 		txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
-		tc.On("Broadcast", mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil)
-		tc.On("Tx", mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil)
+		tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil)
+		tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil)
 		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
 		two := int64(2)
 		cfgMaxMsgs := &config.TOMLConfig{Chain: config.Chain{
@@ -362,7 +754,7 @@ func TestTxm(t *testing.T) {
 		}}
 		cfgMaxMsgs.SetDefaults()
 		loopKs := newKeystore(1)
-		txm := NewTxm(db, tcFn, *gpe, chainID, cfgMaxMsgs, loopKs, lggr)
+		txm := newTxm(t, tcFn, cfgMaxMsgs, loopKs)
 
 		// Leftover started is processed
 		msg1 := generateExecuteMsg([]byte{0x03}, sender1, contract)
@@ -373,10 +765,10 @@ func TestTxm(t *testing.T) {
 			Msg:      []byte{0x03},
 			Contract: contract.String(),
 		}}}
-		tc.On("BatchSimulateUnsigned", msgs, mock.Anything).
+		tc.On("BatchSimulateUnsigned", mock.Anything, msgs, mock.Anything).
 			Return(&client.BatchSimResults{Failed: nil, Succeeded: msgs}, nil).Once()
 		time.Sleep(1 * time.Millisecond)
-		txm.sendMsgBatch(tests.Context(t))
+		sendMsgBatchAndWait(tests.Context(t), txm)
 		m, err := txm.orm.GetMsgs(ctx, id1)
 		require.NoError(t, err)
 		assert.Equal(t, cosmosdb.Confirmed, m[0].State)
@@ -397,20 +789,110 @@ func TestTxm(t *testing.T) {
 			Msg:      []byte{0x05},
 			Contract: contract.String(),
 		}}}
-		tc.On("BatchSimulateUnsigned", msgs, mock.Anything).
+		tc.On("BatchSimulateUnsigned", mock.Anything, msgs, mock.Anything).
 			Return(&client.BatchSimResults{Failed: nil, Succeeded: msgs}, nil).Once()
 		time.Sleep(1 * time.Millisecond)
-		txm.sendMsgBatch(tests.Context(t))
+		sendMsgBatchAndWait(tests.Context(t), txm)
 		require.NoError(t, err)
 		ms, err := txm.orm.GetMsgs(ctx, id2, id3)
 		require.NoError(t, err)
 		assert.Equal(t, cosmosdb.Confirmed, ms[0].State)
 		assert.Equal(t, cosmosdb.Confirmed, ms[1].State)
 	})
+
+	t.Run("busy sender started msgs", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tcFn := func() (client.ReaderWriter, error) { return tc, nil }
+		two := int64(2)
+		cfgMaxMsgs := &config.TOMLConfig{Chain: config.Chain{
+			MaxMsgsPerBatch: &two,
+		}}
+		cfgMaxMsgs.SetDefaults()
+		txm := newTxm(t, tcFn, cfgMaxMsgs, newKeystore(1))
+
+		// sender1 is busy with a full batch of Started msgs
+		for _, b := range []byte{0x06, 0x07} {
+			id := mustInsertMsg(t, txm, contract.String(), generateExecuteMsg([]byte{b}, sender1, contract))
+			require.NoError(t, txm.orm.UpdateMsgs(ctx, []int64{id}, cosmosdb.Started, nil))
+		}
+		txm.workers[sender1.String()] = struct{}{}
+		time.Sleep(time.Millisecond) // ensure != CreatedAt
+		id := mustInsertMsg(t, txm, contract2.String(), generateExecuteMsg([]byte{0x08}, sender2, contract2))
+
+		msgs := client.SimMsgs{{ID: id, Msg: &wasmtypes.MsgExecuteContract{
+			Sender:   sender2.String(),
+			Msg:      []byte{0x08},
+			Contract: contract2.String(),
+		}}}
+		tc.On("BatchSimulateUnsigned", mock.Anything, msgs, mock.Anything).
+			Return(&client.BatchSimResults{Failed: nil, Succeeded: msgs}, nil).Once()
+		tc.On("Account", mock.Anything, sender2).Return(uint64(0), uint64(0), nil).Once()
+		tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+			GasUsed: 1_000_000,
+		}}, nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+			Header: tmservicetypes.Header{Height: 1},
+		}}, nil)
+		tc.On("CreateAndSign", msgs.GetMsgs(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{0x01}, nil).Once()
+		txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
+		tc.On("Broadcast", mock.Anything, []byte{0x01}, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Once()
+		tc.On("Tx", mock.Anything, txResp.TxHash).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Once()
+		sendMsgBatchAndWait(ctx, txm)
+
+		m, err := txm.orm.GetMsgs(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, cosmosdb.Confirmed, m[0].State)
+		started, err := txm.orm.GetMsgsState(ctx, cosmosdb.Started, 10)
+		require.NoError(t, err)
+		assert.Len(t, started, 2)
+	})
+}
+
+// sendMsgBatchAndWait sends a batch and waits for the sender workers to finish.
+func sendMsgBatchAndWait(ctx context.Context, txm *Txm) {
+	txm.sendMsgBatch(ctx)
+	txm.wg.Wait()
+}
+
+// newReaderWriter returns a mock client without websocket support, so that txs are confirmed by polling,
+// and with the default consensus params.
+func newReaderWriter(t *testing.T) *mocks.ReaderWriter {
+	tc := newPollingReaderWriter(t)
+	tc.On("ConsensusParams", mock.Anything).Return(cmttypes.DefaultConsensusParams(), nil).Maybe()
+	return tc
+}
+
+// mockSendBatch mocks tc to send a batch of msgs from sender, signing it only if its msgs match signed.
+// The tx is found as soon as it is broadcast.
+func mockSendBatch(tc *mocks.ReaderWriter, sender cosmostypes.AccAddress, signed any) {
+	tc.On("Account", mock.Anything, sender).Return(uint64(0), uint64(0), nil)
+	tc.On("BatchSimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, msgs client.SimMsgs, _ uint64) *client.BatchSimResults {
+		return &client.BatchSimResults{Succeeded: msgs}
+	}, nil)
+	tc.On("SimulateUnsigned", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.SimulateResponse{GasInfo: &cosmostypes.GasInfo{
+		GasUsed: 1_000_000,
+	}}, nil)
+	tc.On("LatestBlock", mock.Anything).Return(&tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{
+		Header: tmservicetypes.Header{Height: 1},
+	}}, nil)
+	tc.On("CreateAndSign", signed, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x01}, nil)
+	txResp := &cosmostypes.TxResponse{TxHash: "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"}
+	// Not broadcast in ShadowMode
+	tc.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return(&txtypes.BroadcastTxResponse{TxResponse: txResp}, nil).Maybe()
+	tc.On("Tx", mock.Anything, mock.Anything).Return(&txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: txResp}, nil).Maybe()
+}
+
+// newPollingReaderWriter returns a mock client without websocket support, so that txs are confirmed by polling.
+func newPollingReaderWriter(t *testing.T) *mocks.ReaderWriter {
+	tc := mocks.NewReaderWriter(t)
+	tc.On("SubscribeTx", mock.Anything, mock.Anything).Return(nil, errors.New("websocket unavailable")).Maybe()
+	return tc
 }
 
 func mustInsertMsg(t *testing.T, txm *Txm, contractID string, msg cosmostypes.Msg) int64 {
-	typeURL, raw, err := txm.marshalMsg(msg)
+	typeURL, raw, _, err := txm.marshalMsg(msg)
 	require.NoError(t, err)
 	id, err := txm.orm.InsertMsg(tests.Context(t), contractID, typeURL, raw)
 	require.NoError(t, err)
@@ -422,16 +904,25 @@ func RandomChainID() string {
 	return fmt.Sprintf("Chainlinktest-%s", uuid.New())
 }
 
+// keystore is a loop.Keystore holding hex encoded secp256k1 public keys.
 type keystore struct {
 	accounts []string
+	pubKeys  []cryptotypes.PubKey
 }
 
 func newKeystore(count int) *keystore {
-	accounts := make([]string, count)
+	ks := &keystore{}
 	for i := 0; i < count; i++ {
-		accounts[i] = cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes()).String()
+		pubKey := secp256k1.GenPrivKey().PubKey()
+		ks.accounts = append(ks.accounts, hex.EncodeToString(pubKey.Bytes()))
+		ks.pubKeys = append(ks.pubKeys, pubKey)
 	}
-	return &keystore{accounts}
+	return ks
+}
+
+// address returns the account address of the i'th key.
+func (k *keystore) address(i int) cosmostypes.AccAddress {
+	return cosmostypes.AccAddress(k.pubKeys[i].Address())
 }
 
 func (k *keystore) Accounts(ctx context.Context) (accounts []string, err error) {
@@ -444,3 +935,256 @@ func (k *keystore) Sign(ctx context.Context, account string, data []byte) (signe
 	}
 	return data, nil
 }
+
+func TestTxm_bumpGasPrice(t *testing.T) {
+	lggr := logger.Test(t)
+	gasToken := "ucosm"
+	maxGasPrice := decimal.RequireFromString("0.02")
+	bumpMin := decimal.RequireFromString("0.003")
+	bumpPercent := uint16(20)
+	cfg := &config.TOMLConfig{Chain: config.Chain{
+		GasToken:       &gasToken,
+		GasBumpMin:     &bumpMin,
+		GasBumpPercent: &bumpPercent,
+		MaxGasPrice:    &maxGasPrice,
+	}}
+	cfg.SetDefaults()
+	estimated := sdk.NewDecCoinFromDec(gasToken, sdk.MustNewDecFromStr("0.01"))
+	gpe := client.NewComposedGasPriceEstimator([]client.GasPricesEstimator{
+		client.NewFixedGasPriceEstimator(map[string]sdk.DecCoin{gasToken: estimated}, logger.Sugared(lggr)),
+	}, nil, lggr)
+	chainID := RandomChainID()
+	txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, gpe, chainID, cfg, newKeystore(1), lggr)
+
+	for _, tt := range []struct {
+		name     string
+		previous string
+		want     string
+		wantErr  bool
+	}{
+		{name: "min", previous: "0.01", want: "0.013"},
+		{name: "percent", previous: "0.016", want: "0.0192"},
+		{name: "estimated price", previous: "0.001", want: "0.01"},
+		{name: "capped", previous: "0.019", want: "0.02"},
+		{name: "at max", previous: "0.02", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := txm.bumpGasPrice(sdk.NewDecCoinFromDec(gasToken, sdk.MustNewDecFromStr(tt.previous)))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, sdk.NewDecCoinFromDec(gasToken, sdk.MustNewDecFromStr(tt.want)), got)
+		})
+	}
+
+	t.Run("no cap", func(t *testing.T) {
+		noCap := decimal.Zero
+		cfg := &config.TOMLConfig{Chain: config.Chain{
+			GasToken:       &gasToken,
+			GasBumpMin:     &bumpMin,
+			GasBumpPercent: &bumpPercent,
+			MaxGasPrice:    &noCap,
+		}}
+		cfg.SetDefaults()
+		txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, gpe, chainID, cfg, newKeystore(1), lggr)
+		got, err := txm.bumpGasPrice(sdk.NewDecCoinFromDec(gasToken, sdk.MustNewDecFromStr("0.5")))
+		require.NoError(t, err)
+		assert.Equal(t, sdk.NewDecCoinFromDec(gasToken, sdk.MustNewDecFromStr("0.6")), got)
+	})
+}
+
+func TestTxm_marshalMsg(t *testing.T) {
+	lggr := logger.Test(t)
+	cfg := &config.TOMLConfig{}
+	cfg.SetDefaults()
+	chainID := RandomChainID()
+	txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, nil, chainID, cfg, newKeystore(1), lggr)
+	ks := newKeystore(2)
+	from, to := ks.address(0), ks.address(1)
+
+	for _, tt := range []struct {
+		name string
+		msg  cosmostypes.Msg
+	}{
+		{name: "send", msg: banktypes.NewMsgSend(from, to, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("ucosm", 1)))},
+		{name: "execute", msg: &wasmtypes.MsgExecuteContract{Sender: from.String(), Contract: to.String(), Msg: []byte(`{"foo":"bar"}`)}},
+		{name: "migrate", msg: &wasmtypes.MsgMigrateContract{Sender: from.String(), Contract: to.String(), CodeID: 2, Msg: []byte(`{}`)}},
+		{name: "injective transmit", msg: &chaintypes.MsgTransmit{Transmitter: from.String(), FeedId: "feed", Epoch: 1, Round: 2}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			typeURL, raw, sender, err := txm.marshalMsg(tt.msg)
+			require.NoError(t, err)
+			assert.Equal(t, from.String(), sender)
+			assert.Equal(t, cosmostypes.MsgTypeURL(tt.msg), typeURL)
+
+			msg, sender, err := unmarshalMsg(typeURL, raw)
+			require.NoError(t, err)
+			assert.Equal(t, from.String(), sender)
+			assert.Equal(t, tt.msg, msg)
+		})
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		// to executes a send on behalf of from
+		exec := authz.NewMsgExec(to, []cosmostypes.Msg{banktypes.NewMsgSend(from, to, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("ucosm", 1)))})
+		typeURL, raw, sender, err := txm.marshalMsg(&exec)
+		require.NoError(t, err)
+		assert.Equal(t, to.String(), sender)
+
+		msg, sender, err := unmarshalMsg(typeURL, raw)
+		require.NoError(t, err)
+		assert.Equal(t, to.String(), sender)
+		got, ok := msg.(*authz.MsgExec)
+		require.True(t, ok)
+		assert.Equal(t, exec.Grantee, got.Grantee)
+		require.Len(t, got.Msgs, 1)
+		assert.Equal(t, exec.Msgs[0].TypeUrl, got.Msgs[0].TypeUrl)
+		assert.Equal(t, exec.Msgs[0].Value, got.Msgs[0].Value)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, _, _, err := txm.marshalMsg(&banktypes.MsgMultiSend{})
+		var errUnsupported *ErrMsgUnsupported
+		require.ErrorAs(t, err, &errUnsupported)
+
+		_, _, err = unmarshalMsg(cosmostypes.MsgTypeURL(&banktypes.MsgMultiSend{}), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestTxm_MemoryStorage(t *testing.T) {
+	ctx := tests.Context(t)
+	lggr := logger.Test(t)
+	cfg := &config.TOMLConfig{}
+	cfg.SetDefaults()
+	chainID := RandomChainID()
+	txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, nil, chainID, cfg, newKeystore(1), lggr)
+	ks := newKeystore(2)
+	from, to := ks.address(0), ks.address(1)
+
+	id1, err := txm.Enqueue(ctx, to.String(), generateExecuteMsg([]byte(`1`), from, to))
+	require.NoError(t, err)
+	id2, err := txm.Enqueue(ctx, to.String(), generateExecuteMsg([]byte(`2`), from, to))
+	require.NoError(t, err)
+
+	msgs, err := txm.GetMsgs(ctx, id1, id2)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, cosmosdb.Errored, msgs[0].State)
+	require.NotNil(t, msgs[0].Reason)
+	assert.Equal(t, cosmosdb.Replaced, *msgs[0].Reason)
+	assert.Equal(t, cosmosdb.Unstarted, msgs[1].State)
+}
+
+func TestTxm_waitForTx(t *testing.T) {
+	lggr := logger.Test(t)
+	cfg := &config.TOMLConfig{}
+	cfg.SetDefaults()
+	chainID := RandomChainID()
+	txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, nil, chainID, cfg, newKeystore(1), lggr)
+	txHash := "4BF5122F344554C53BDE2EBB8CD2B7E3D1600AD631C385A5D7CCE23C7785459A"
+	txResp := &txtypes.GetTxResponse{Tx: &txtypes.Tx{}, TxResponse: &cosmostypes.TxResponse{TxHash: txHash}}
+	notFound := client.ErrTxNotFound
+	latestBlock := func(height int64) *tmservicetypes.GetLatestBlockResponse {
+		return &tmservicetypes.GetLatestBlockResponse{SdkBlock: &tmservicetypes.Block{Header: tmservicetypes.Header{Height: height}}}
+	}
+	const timeoutHeight = 10
+
+	t.Run("polling", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Times(3)
+		tc.On("Tx", mock.Anything, txHash).Return(nil, notFound).Twice()
+		tc.On("Tx", mock.Anything, txHash).Return(txResp, nil).Once()
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txResp, tx)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Twice()
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight+1), nil).Once()
+		tc.On("Tx", mock.Anything, txHash).Return(nil, notFound).Times(3)
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("not expired on errors", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(nil, errors.New("unavailable")).Once()
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight+1), nil).Twice()
+		tc.On("Tx", mock.Anything, txHash).Return(nil, errors.New("connection refused")).Once()
+		tc.On("Tx", mock.Anything, txHash).Return(txResp, nil).Once()
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txResp, tx)
+	})
+
+	t.Run("timed out on errors", func(t *testing.T) {
+		ctx := tests.Context(t)
+		blocks := int64(2)
+		cfgShort := &config.TOMLConfig{Chain: config.Chain{
+			BlockRate:            commoncfg.MustNewDuration(5 * time.Millisecond),
+			BlocksUntilTxTimeout: &blocks,
+		}}
+		cfgShort.SetDefaults()
+		txm := NewTxmWithStorage(NewMemoryStorage(chainID), nil, nil, chainID, cfgShort, newKeystore(1), lggr)
+		tc := newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(nil, errors.New("unavailable")).Maybe()
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+
+		tc = newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Maybe()
+		tc.On("Tx", mock.Anything, txHash).Return(nil, errors.New("connection refused")).Maybe()
+		tx, err = txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+
+		tc = newReaderWriter(t)
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Maybe()
+		tc.On("Tx", mock.Anything, txHash).Return(&txtypes.GetTxResponse{}, nil).Maybe()
+		tx, err = txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("subscription", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		included := make(chan struct{}, 1)
+		tc.On("SubscribeTx", mock.Anything, txHash).Return((<-chan struct{})(included), nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Twice()
+		// Not polled until included, except every subscribedPollEvery periods
+		tc.On("Tx", mock.Anything, txHash).Return(nil, notFound).Once()
+		tc.On("Tx", mock.Anything, txHash).Return(txResp, nil).Once()
+		go func() {
+			time.Sleep(70 * time.Millisecond) // after the first poll
+			included <- struct{}{}
+		}()
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txResp, tx)
+	})
+
+	t.Run("subscription ended", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tc := mocks.NewReaderWriter(t)
+		included := make(chan struct{})
+		close(included)
+		tc.On("SubscribeTx", mock.Anything, txHash).Return((<-chan struct{})(included), nil).Once()
+		tc.On("LatestBlock", mock.Anything).Return(latestBlock(timeoutHeight), nil).Times(3)
+		tc.On("Tx", mock.Anything, txHash).Return(nil, notFound).Twice()
+		tc.On("Tx", mock.Anything, txHash).Return(txResp, nil).Once()
+		tx, err := txm.waitForTx(ctx, tc, txHash, timeoutHeight, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txResp, tx)
+	})
+}